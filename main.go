@@ -1,11 +1,28 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"log"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/opsgenie/terraform-provider-opsgenie/opsgenie"
 )
 
 func main() {
+	var export bool
+	var exportDir string
+	flag.BoolVar(&export, "export", false, "export the Opsgenie account configured with OPSGENIE_API_KEY as Terraform configuration instead of serving the provider")
+	flag.StringVar(&exportDir, "export-dir", ".", "directory the exported configuration is written to")
+	flag.Parse()
+
+	if export {
+		if err := opsgenie.Export(context.Background(), exportDir); err != nil {
+			log.Fatalf("Error exporting the Opsgenie account: %s", err)
+		}
+		return
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: opsgenie.Provider})
 }
@@ -0,0 +1,307 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
+)
+
+// The conditions of policy filters, routing rule and notification rule
+// criteria, incident rules and integration actions share the definitions
+// below, so a condition valid for one of them is valid for all of them.
+
+var conditionMatchTypes = []string{"match-all", "match-any-condition", "match-all-conditions"}
+
+var conditionOperations = []string{
+	"matches", "contains", "starts-with", "ends-with", "equals", "contains-key",
+	"contains-value", "greater-than", "less-than", "is-empty", "equals-ignore-whitespace",
+}
+
+var conditionTextOperations = []string{
+	"matches", "contains", "starts-with", "ends-with", "equals", "is-empty", "equals-ignore-whitespace",
+}
+
+var conditionListOperations = []string{"matches", "contains", "is-empty"}
+
+// conditionFieldOperations lists the operations Opsgenie accepts for each
+// condition field. Fields not listed, like the fields of email integrations,
+// accept any operation.
+var conditionFieldOperations = map[string][]string{
+	"message":          conditionTextOperations,
+	"alias":            conditionTextOperations,
+	"description":      conditionTextOperations,
+	"source":           conditionTextOperations,
+	"entity":           conditionTextOperations,
+	"extra-properties": conditionTextOperations,
+	"tags":             conditionListOperations,
+	"actions":          conditionListOperations,
+	"recipients":       conditionListOperations,
+	"responders":       conditionListOperations,
+	"teams":            conditionListOperations,
+	"details":          {"contains", "is-empty", "contains-key", "contains-value"},
+	"priority":         {"equals", "greater-than", "less-than"},
+}
+
+var conditionPriorities = []string{"P1", "P2", "P3", "P4", "P5"}
+
+// policyConditionFields are the fields of alert and notification policy
+// filter conditions.
+var policyConditionFields = []string{
+	"message", "alias", "description", "source", "entity", "tags",
+	"actions", "details", "extra-properties", "responders", "teams", "priority",
+}
+
+// criteriaConditionFields are the fields of routing rule, notification rule
+// and incident rule conditions.
+var criteriaConditionFields = []string{
+	"message", "alias", "description", "source", "entity", "tags",
+	"actions", "details", "extra-properties", "recipients", "teams", "priority",
+}
+
+// conditionsSchema returns the schema of the conditions of a filter or
+// criteria. fields restricts the condition fields, nil allows any field.
+func conditionsSchema(valueType schema.ValueType, fields []string) *schema.Schema {
+	field := &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	if fields != nil {
+		field.ValidateFunc = validation.StringInSlice(fields, false)
+	}
+
+	return &schema.Schema{
+		Type:     valueType,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"field": field,
+				"operation": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(conditionOperations, false),
+				},
+				"key": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Key of the extra property, required if 'field' is set as 'extra-properties'",
+				},
+				"not": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Indicates behaviour of the given operation. Default value is false",
+					Default:     false,
+				},
+				"expected_value": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "User defined value that will be compared with alert field according to the operation. Default value is empty string",
+				},
+				"order": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Description: "Order of the condition in conditions list",
+				},
+			},
+		},
+	}
+}
+
+func expandOpsgenieConditions(input []interface{}) []og.Condition {
+	conditions := make([]og.Condition, 0, len(input))
+	for _, v := range input {
+		config := v.(map[string]interface{})
+		isNot := config["not"].(bool)
+		condition := og.Condition{
+			Field:         og.ConditionFieldType(config["field"].(string)),
+			Operation:     og.ConditionOperation(config["operation"].(string)),
+			IsNot:         &isNot,
+			ExpectedValue: config["expected_value"].(string),
+			Key:           config["key"].(string),
+		}
+		if order, ok := config["order"].(int); ok {
+			condition.Order = &order
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions
+}
+
+// flattenOpsgenieConditions flattens conditions, withOrder tells whether the
+// schema of the conditions has an order.
+func flattenOpsgenieConditions(input []og.Condition, withOrder bool) []map[string]interface{} {
+	output := make([]map[string]interface{}, 0, len(input))
+	for _, v := range input {
+		element := make(map[string]interface{})
+		element["field"] = v.Field
+		element["operation"] = v.Operation
+		element["key"] = v.Key
+		element["not"] = v.IsNot != nil && *v.IsNot
+		element["expected_value"] = v.ExpectedValue
+		if withOrder && v.Order != nil {
+			element["order"] = *v.Order
+		}
+		output = append(output, element)
+	}
+	return output
+}
+
+// conditionsBlock locates the blocks of a resource holding a match type and
+// conditions. path holds the names of the nested blocks leading to them,
+// e.g. "create", "filter" for the filters of integration create actions.
+type conditionsBlock struct {
+	path      []string
+	matchType string
+}
+
+// validateConditionsDiff returns a CustomizeDiffFunc validating the
+// conditions of blocks at plan time, instead of having Opsgenie reject them
+// while applying. Values not known yet are validated on the next plan.
+func validateConditionsDiff(blocks ...conditionsBlock) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		return validateConditionsConfig(d.GetRawConfig(), blocks...)
+	}
+}
+
+func validateConditionsConfig(config cty.Value, blocks ...conditionsBlock) error {
+	for _, b := range blocks {
+		for _, block := range conditionsBlockValues(config, b.path) {
+			if err := validateConditionsBlock(b, block); err != nil {
+				return fmt.Errorf("%s: %s", strings.Join(b.path, "."), err)
+			}
+		}
+	}
+	return nil
+}
+
+func conditionsBlockValues(v cty.Value, path []string) []cty.Value {
+	if len(path) == 0 {
+		return []cty.Value{v}
+	}
+	if v.IsNull() || !v.IsKnown() || !v.Type().IsObjectType() || !v.Type().HasAttribute(path[0]) {
+		return nil
+	}
+
+	nested := v.GetAttr(path[0])
+	if nested.IsNull() || !nested.IsKnown() || !nested.CanIterateElements() {
+		return nil
+	}
+	var values []cty.Value
+	for it := nested.ElementIterator(); it.Next(); {
+		_, element := it.Element()
+		values = append(values, conditionsBlockValues(element, path[1:])...)
+	}
+	return values
+}
+
+func validateConditionsBlock(b conditionsBlock, block cty.Value) error {
+	if block.IsNull() || !block.IsKnown() {
+		return nil
+	}
+
+	var conditions []cty.Value
+	conditionsKnown := true
+	if c := block.GetAttr("conditions"); !c.IsKnown() {
+		conditionsKnown = false
+	} else if !c.IsNull() {
+		for it := c.ElementIterator(); it.Next(); {
+			_, condition := it.Element()
+			conditions = append(conditions, condition)
+		}
+	}
+
+	// the match type defaults to match-all wherever it is optional
+	matchType, ok := "match-all", true
+	if t := block.GetAttr(b.matchType); !t.IsNull() {
+		matchType, ok = conditionString(t)
+	}
+	if ok && conditionsKnown {
+		if err := validateConditionMatchType(b.matchType, matchType, len(conditions)); err != nil {
+			return err
+		}
+	}
+
+	for _, condition := range conditions {
+		if condition.IsNull() || !condition.IsKnown() {
+			continue
+		}
+		field, fieldKnown := conditionString(condition.GetAttr("field"))
+		operation, operationKnown := conditionString(condition.GetAttr("operation"))
+		if !fieldKnown || !operationKnown {
+			continue
+		}
+
+		var key, expectedValue *string
+		if v, ok := conditionString(condition.GetAttr("key")); ok {
+			key = &v
+		}
+		if v, ok := conditionString(condition.GetAttr("expected_value")); ok {
+			expectedValue = &v
+		}
+		if err := validateCondition(field, operation, key, expectedValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// conditionString returns the string held by v, null being the empty
+// string. It returns false if the value isn't known yet.
+func conditionString(v cty.Value) (string, bool) {
+	if !v.IsKnown() {
+		return "", false
+	}
+	if v.IsNull() {
+		return "", true
+	}
+	return v.AsString(), true
+}
+
+func validateConditionMatchType(attribute, matchType string, conditions int) error {
+	switch matchType {
+	case "match-all":
+		if conditions > 0 {
+			return fmt.Errorf("conditions cannot be set when %s is match-all", attribute)
+		}
+	case "match-any-condition", "match-all-conditions":
+		if conditions == 0 {
+			return fmt.Errorf("at least one condition is required when %s is %s", attribute, matchType)
+		}
+	}
+	return nil
+}
+
+// validateCondition validates a condition against the operations Opsgenie
+// accepts for its field. key and expectedValue are nil if not known yet.
+func validateCondition(field, operation string, key, expectedValue *string) error {
+	if operations, ok := conditionFieldOperations[field]; ok && !containsString(operations, operation) {
+		return fmt.Errorf("operation %q is not valid for field %q, expected one of %s", operation, field, strings.Join(operations, ", "))
+	}
+
+	if key != nil {
+		if field == string(og.ExtraProperties) && *key == "" {
+			return fmt.Errorf("key is required for field %q", field)
+		}
+		if field != string(og.ExtraProperties) && *key != "" {
+			return fmt.Errorf("key is only valid for field %q, not %q", og.ExtraProperties, field)
+		}
+	}
+
+	if field == string(og.Priority) && expectedValue != nil && !containsString(conditionPriorities, *expectedValue) {
+		return fmt.Errorf("expected_value %q is not valid for field %q, expected one of %s", *expectedValue, field, strings.Join(conditionPriorities, ", "))
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
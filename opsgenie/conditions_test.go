@@ -0,0 +1,107 @@
+package opsgenie
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestValidateConditionsConfig(t *testing.T) {
+	condition := func(field, operation, key, expectedValue cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"field":          field,
+			"operation":      operation,
+			"key":            key,
+			"expected_value": expectedValue,
+		})
+	}
+	config := func(matchType cty.Value, conditions ...cty.Value) cty.Value {
+		list := cty.ListValEmpty(condition(cty.NullVal(cty.String), cty.NullVal(cty.String), cty.NullVal(cty.String), cty.NullVal(cty.String)).Type())
+		if len(conditions) > 0 {
+			list = cty.ListVal(conditions)
+		}
+		filter := cty.ObjectVal(map[string]cty.Value{
+			"type":       matchType,
+			"conditions": list,
+		})
+		return cty.ObjectVal(map[string]cty.Value{
+			"create": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"filter": cty.ListVal([]cty.Value{filter}),
+			})}),
+		})
+	}
+	null := cty.NullVal(cty.String)
+	unknown := cty.UnknownVal(cty.String)
+	message := condition(cty.StringVal("message"), cty.StringVal("contains"), null, cty.StringVal("test"))
+
+	cases := []struct {
+		name   string
+		config cty.Value
+		err    string
+	}{
+		{name: "match-all", config: config(cty.StringVal("match-all"))},
+		{name: "default match type", config: config(null)},
+		{name: "match-any-condition", config: config(cty.StringVal("match-any-condition"), message)},
+		{name: "match-all with conditions", config: config(cty.StringVal("match-all"), message), err: "conditions cannot be set when type is match-all"},
+		{name: "match-all-conditions without conditions", config: config(cty.StringVal("match-all-conditions")), err: "at least one condition is required"},
+		{name: "unknown match type", config: config(unknown, message)},
+		{
+			name:   "invalid operation",
+			config: config(cty.StringVal("match-all-conditions"), condition(cty.StringVal("tags"), cty.StringVal("equals"), null, cty.StringVal("test"))),
+			err:    `operation "equals" is not valid for field "tags"`,
+		},
+		{
+			name:   "teams with a text operation",
+			config: config(cty.StringVal("match-all-conditions"), condition(cty.StringVal("teams"), cty.StringVal("starts-with"), null, cty.StringVal("test"))),
+			err:    `operation "starts-with" is not valid for field "teams"`,
+		},
+		{
+			name:   "teams",
+			config: config(cty.StringVal("match-all-conditions"), condition(cty.StringVal("teams"), cty.StringVal("contains"), null, cty.StringVal("test"))),
+		},
+		{
+			name:   "unrestricted field",
+			config: config(cty.StringVal("match-all-conditions"), condition(cty.StringVal("from_address"), cty.StringVal("equals"), null, cty.StringVal("test"))),
+		},
+		{
+			name:   "extra-properties",
+			config: config(cty.StringVal("match-all-conditions"), condition(cty.StringVal("extra-properties"), cty.StringVal("equals"), cty.StringVal("env"), cty.StringVal("prod"))),
+		},
+		{
+			name:   "extra-properties without key",
+			config: config(cty.StringVal("match-all-conditions"), condition(cty.StringVal("extra-properties"), cty.StringVal("equals"), null, cty.StringVal("prod"))),
+			err:    `key is required for field "extra-properties"`,
+		},
+		{
+			name:   "extra-properties with unknown key",
+			config: config(cty.StringVal("match-all-conditions"), condition(cty.StringVal("extra-properties"), cty.StringVal("equals"), unknown, cty.StringVal("prod"))),
+		},
+		{
+			name:   "key on another field",
+			config: config(cty.StringVal("match-all-conditions"), condition(cty.StringVal("message"), cty.StringVal("equals"), cty.StringVal("env"), cty.StringVal("prod"))),
+			err:    `key is only valid for field "extra-properties"`,
+		},
+		{
+			name:   "invalid priority",
+			config: config(cty.StringVal("match-all-conditions"), condition(cty.StringVal("priority"), cty.StringVal("equals"), null, cty.StringVal("high"))),
+			err:    `expected_value "high" is not valid for field "priority"`,
+		},
+		{
+			name:   "unknown priority",
+			config: config(cty.StringVal("match-all-conditions"), condition(cty.StringVal("priority"), cty.StringVal("equals"), null, unknown)),
+		},
+	}
+	for _, c := range cases {
+		err := validateConditionsConfig(c.config, conditionsBlock{path: []string{"create", "filter"}, matchType: "type"})
+		if c.err == "" {
+			if err != nil {
+				t.Errorf("%s: expected no error, got %s", c.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s: expected an error containing %q, got %v", c.name, c.err, err)
+		}
+	}
+}
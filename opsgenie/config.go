@@ -3,14 +3,39 @@ package opsgenie
 import (
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/alert"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/client"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/contact"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/custom_user_role"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/escalation"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/heartbeat"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/incident"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/integration"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/maintenance"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/notification"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/policy"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/schedule"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/service"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/team"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/user"
 )
 
 type OpsgenieClient struct {
 	client *client.OpsGenieClient
+
+	// clients holds the SDK clients of every API package, built on first use
+	// and shared by all resources and data sources.
+	clientsMu sync.Mutex
+	clients   map[string]interface{}
+
+	// locks serialize the read-modify-write updates of objects shared by
+	// several resources, e.g. the actions of an integration.
+	locksMu sync.Mutex
+	locks   map[string]*sync.Mutex
 }
 
 type Config struct {
@@ -19,6 +44,10 @@ type Config struct {
 	ApiRetryCount   int
 	ApiRetryWaitMin int
 	ApiRetryWaitMax int
+
+	ApiRateLimit        float64
+	ApiRateLimitBurst   int
+	ApiRateLimitDomains map[string]float64
 }
 
 func (c *Config) Client() (*OpsgenieClient, error) {
@@ -33,6 +62,9 @@ func (c *Config) Client() (*OpsgenieClient, error) {
 				return retryablehttp.DefaultBackoff(min, max, attemptNum, resp)
 			}
 		},
+		HttpClient: &http.Client{
+			Transport: newRateLimitedTransport(http.DefaultTransport.(*http.Transport).Clone(), c.ApiRateLimit, c.ApiRateLimitBurst, c.ApiRateLimitDomains),
+		},
 	}
 	ogCli, err := client.NewOpsGenieClient(config)
 	if err != nil {
@@ -43,3 +75,182 @@ func (c *Config) Client() (*OpsgenieClient, error) {
 	log.Printf("[INFO] OpsGenie client configured")
 	return &ogClient, nil
 }
+
+// cachedClient returns the SDK client registered under name, creating it with
+// newClient the first time it is requested.
+func (c *OpsgenieClient) cachedClient(name string, newClient func(config *client.Config) (interface{}, error)) (interface{}, error) {
+	c.clientsMu.Lock()
+	defer c.clientsMu.Unlock()
+
+	if cli, ok := c.clients[name]; ok {
+		return cli, nil
+	}
+	cli, err := newClient(c.client.Config)
+	if err != nil {
+		return nil, err
+	}
+	if c.clients == nil {
+		c.clients = make(map[string]interface{})
+	}
+	c.clients[name] = cli
+	log.Printf("[DEBUG] OpsGenie %s client configured", name)
+	return cli, nil
+}
+
+// lock locks the object identified by key and returns the function to unlock
+// it.
+func (c *OpsgenieClient) lock(key string) func() {
+	c.locksMu.Lock()
+	if c.locks == nil {
+		c.locks = make(map[string]*sync.Mutex)
+	}
+	l, ok := c.locks[key]
+	if !ok {
+		l = &sync.Mutex{}
+		c.locks[key] = l
+	}
+	c.locksMu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+func (c *OpsgenieClient) alertClient() (*alert.Client, error) {
+	cli, err := c.cachedClient("alert", func(config *client.Config) (interface{}, error) {
+		return alert.NewClient(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cli.(*alert.Client), nil
+}
+
+func (c *OpsgenieClient) contactClient() (*contact.Client, error) {
+	cli, err := c.cachedClient("contact", func(config *client.Config) (interface{}, error) {
+		return contact.NewClient(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cli.(*contact.Client), nil
+}
+
+func (c *OpsgenieClient) customUserRoleClient() (*custom_user_role.Client, error) {
+	cli, err := c.cachedClient("custom_user_role", func(config *client.Config) (interface{}, error) {
+		return custom_user_role.NewClient(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cli.(*custom_user_role.Client), nil
+}
+
+func (c *OpsgenieClient) escalationClient() (*escalation.Client, error) {
+	cli, err := c.cachedClient("escalation", func(config *client.Config) (interface{}, error) {
+		return escalation.NewClient(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cli.(*escalation.Client), nil
+}
+
+func (c *OpsgenieClient) heartbeatClient() (*heartbeat.Client, error) {
+	cli, err := c.cachedClient("heartbeat", func(config *client.Config) (interface{}, error) {
+		return heartbeat.NewClient(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cli.(*heartbeat.Client), nil
+}
+
+func (c *OpsgenieClient) incidentClient() (*incident.Client, error) {
+	cli, err := c.cachedClient("incident", func(config *client.Config) (interface{}, error) {
+		return incident.NewClient(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cli.(*incident.Client), nil
+}
+
+func (c *OpsgenieClient) integrationClient() (*integration.Client, error) {
+	cli, err := c.cachedClient("integration", func(config *client.Config) (interface{}, error) {
+		return integration.NewClient(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cli.(*integration.Client), nil
+}
+
+func (c *OpsgenieClient) maintenanceClient() (*maintenance.Client, error) {
+	cli, err := c.cachedClient("maintenance", func(config *client.Config) (interface{}, error) {
+		return maintenance.NewClient(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cli.(*maintenance.Client), nil
+}
+
+func (c *OpsgenieClient) notificationClient() (*notification.Client, error) {
+	cli, err := c.cachedClient("notification", func(config *client.Config) (interface{}, error) {
+		return notification.NewClient(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cli.(*notification.Client), nil
+}
+
+func (c *OpsgenieClient) policyClient() (*policy.Client, error) {
+	cli, err := c.cachedClient("policy", func(config *client.Config) (interface{}, error) {
+		return policy.NewClient(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cli.(*policy.Client), nil
+}
+
+func (c *OpsgenieClient) scheduleClient() (*schedule.Client, error) {
+	cli, err := c.cachedClient("schedule", func(config *client.Config) (interface{}, error) {
+		return schedule.NewClient(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cli.(*schedule.Client), nil
+}
+
+func (c *OpsgenieClient) serviceClient() (*service.Client, error) {
+	cli, err := c.cachedClient("service", func(config *client.Config) (interface{}, error) {
+		return service.NewClient(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cli.(*service.Client), nil
+}
+
+func (c *OpsgenieClient) teamClient() (*team.Client, error) {
+	cli, err := c.cachedClient("team", func(config *client.Config) (interface{}, error) {
+		return team.NewClient(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cli.(*team.Client), nil
+}
+
+func (c *OpsgenieClient) userClient() (*user.Client, error) {
+	cli, err := c.cachedClient("user", func(config *client.Config) (interface{}, error) {
+		return user.NewClient(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cli.(*user.Client), nil
+}
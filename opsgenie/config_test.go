@@ -0,0 +1,44 @@
+package opsgenie
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/opsgenie/opsgenie-go-sdk-v2/team"
+)
+
+func TestOpsgenieClient_cachedClients(t *testing.T) {
+	config := Config{
+		ApiKey:            "test-key",
+		ApiUrl:            "api.opsgenie.com",
+		ApiRateLimitBurst: 1,
+	}
+	cli, err := config.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clients := make([]*team.Client, 10)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i], _ = cli.teamClient()
+		}(i)
+	}
+	wg.Wait()
+
+	for i, c := range clients {
+		if c == nil || c != clients[0] {
+			t.Fatalf("Expected every call to return the same team client, call %d returned %p", i, c)
+		}
+	}
+
+	if _, err := cli.userClient(); err != nil {
+		t.Fatal(err)
+	}
+	if len(cli.clients) != 2 {
+		t.Errorf("Expected 2 cached clients, got %d", len(cli.clients))
+	}
+}
@@ -3,15 +3,16 @@ package opsgenie
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/escalation"
 )
 
 func dataSourceOpsgenieEscalation() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceOpsgenieEscalationRead,
+		ReadContext: dataSourceOpsgenieEscalationRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -97,10 +98,10 @@ func dataSourceOpsgenieEscalation() *schema.Resource {
 	}
 }
 
-func dataSourceOpsgenieEscalationRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := escalation.NewClient(meta.(*OpsgenieClient).client.Config)
+func dataSourceOpsgenieEscalationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).escalationClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	escalationName := d.Get("name").(string)
 
@@ -109,16 +110,18 @@ func dataSourceOpsgenieEscalationRead(d *schema.ResourceData, meta interface{})
 		Identifier:     escalationName,
 	}
 
-	getResponse, err := client.Get(context.Background(), getRequest)
+	getResponse, err := client.Get(ctx, getRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(getResponse.Id)
 	d.Set("name", getResponse.Name)
 	d.Set("description", getResponse.Description)
 	d.Set("rules", flattenOpsgenieEscalationRules(getResponse.Rules))
-	d.Set("repeat", flattenOpsgenieEscalationRepeat(getResponse.Repeat))
+	if getResponse.Repeat != nil {
+		d.Set("repeat", flattenOpsgenieEscalationRepeat(getResponse.Repeat))
+	}
 	if getResponse.OwnerTeam != nil {
 		d.Set("owner_team_id", getResponse.OwnerTeam.Id)
 	}
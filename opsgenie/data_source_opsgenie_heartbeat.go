@@ -2,15 +2,20 @@ package opsgenie
 
 import (
 	"context"
+	"errors"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/client"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/heartbeat"
 )
 
 func dataSourceOpsgenieHeartbeat() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceOpsgenieHeartbeatRead,
+		ReadContext: dataSourceOpsgenieHeartbeatRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -54,21 +59,52 @@ func dataSourceOpsgenieHeartbeat() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"expired": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"last_ping_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func dataSourceOpsgenieHeartbeatRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := heartbeat.NewClient(meta.(*OpsgenieClient).client.Config)
-	if err != nil {
-		return err
+// getHeartbeatStatusRequest gets a heartbeat along with the time of its last
+// ping, which heartbeat.Client.Get leaves out.
+type getHeartbeatStatusRequest struct {
+	client.BaseRequest
+	HeartbeatName string
+}
+
+func (r *getHeartbeatStatusRequest) Validate() error {
+	if r.HeartbeatName == "" {
+		return errors.New("Heartbeat name cannot be empty.")
 	}
+	return nil
+}
+
+func (r *getHeartbeatStatusRequest) ResourcePath() string {
+	return "/v2/heartbeats/" + r.HeartbeatName
+}
+
+func (r *getHeartbeatStatusRequest) Method() string {
+	return http.MethodGet
+}
+
+type getHeartbeatStatusResult struct {
+	heartbeat.GetResult
+	LastPingTime string `json:"lastPingTime"`
+}
 
+func dataSourceOpsgenieHeartbeatRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	heartbeatName := d.Get("name").(string)
 
-	result, err := client.Get(context.Background(), heartbeatName)
+	result := &getHeartbeatStatusResult{}
+	err := meta.(*OpsgenieClient).client.Exec(ctx, &getHeartbeatStatusRequest{HeartbeatName: heartbeatName}, result)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(result.Name)
@@ -81,6 +117,8 @@ func dataSourceOpsgenieHeartbeatRead(d *schema.ResourceData, meta interface{}) e
 	err = d.Set("alert_priority", result.AlertPriority)
 	err = d.Set("alert_tags", result.AlertTags)
 	err = d.Set("alert_message", result.AlertMessage)
+	err = d.Set("expired", result.Expired)
+	err = d.Set("last_ping_time", result.LastPingTime)
 
 	return nil
 }
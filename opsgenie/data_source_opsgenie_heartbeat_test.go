@@ -26,6 +26,23 @@ func TestAccDataSourceOpsGenieHeartbeat_Basic(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceOpsGenieHeartbeat_status(t *testing.T) {
+	randomName := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceOpsGenieHeartbeatStatusConfig(randomName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.opsgenie_heartbeat.pinged", "expired", "false"),
+					resource.TestCheckResourceAttrSet("data.opsgenie_heartbeat.pinged", "last_ping_time"),
+				),
+			},
+		},
+	})
+}
+
 func testAccDataSourceOpsGenieHeartbeat(src, n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
@@ -76,3 +93,18 @@ data "opsgenie_heartbeat" "existingheartbeat" {
 }
 `, randomTeamName, randomName)
 }
+
+func testAccDataSourceOpsGenieHeartbeatStatusConfig(randomName string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_heartbeat" "test" {
+  name          = "genieheartbeat-%s"
+  interval_unit = "minutes"
+  interval      = 10
+  enabled       = true
+  initial_ping  = true
+}
+data "opsgenie_heartbeat" "pinged" {
+  name = opsgenie_heartbeat.test.name
+}
+`, randomName)
+}
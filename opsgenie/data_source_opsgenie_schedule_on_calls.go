@@ -0,0 +1,233 @@
+package opsgenie
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/schedule"
+)
+
+func dataSourceOpsgenieScheduleOnCalls() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceOpsgenieScheduleOnCallsRead,
+		Schema: map[string]*schema.Schema{
+			"schedule_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"schedule_id", "schedule_name"},
+			},
+			"schedule_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"schedule_id", "schedule_name"},
+			},
+			"date": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDate,
+			},
+			"flat": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"on_call_participants": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: onCallParticipantSchema(map[string]*schema.Schema{
+						"on_call_participants": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: onCallParticipantSchema(map[string]*schema.Schema{}),
+							},
+						},
+					}),
+				},
+			},
+			"on_call_recipients": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"next_on_call_recipients": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     nextOnCallRecipientResource(),
+			},
+			"exact_next_on_call_recipients": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     nextOnCallRecipientResource(),
+			},
+			"next_on_call_participants": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"exact_next_on_call_participants": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func onCallParticipantSchema(extra map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"escalation_time": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"notify_type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+	for k, v := range extra {
+		s[k] = v
+	}
+	return s
+}
+
+func nextOnCallRecipientResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"on_call_participants": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: onCallParticipantSchema(map[string]*schema.Schema{}),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOpsgenieScheduleOnCallsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).scheduleClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	identifierType := schedule.Id
+	identifier := d.Get("schedule_id").(string)
+	if identifier == "" {
+		identifierType = schedule.Name
+		identifier = d.Get("schedule_name").(string)
+	}
+	flat := d.Get("flat").(bool)
+
+	var date *time.Time
+	if v := d.Get("date").(string); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return diag.Errorf("Cannot parse date: %s", err)
+		}
+		date = &parsed
+	}
+
+	onCalls, err := client.GetOnCalls(ctx, &schedule.GetOnCallsRequest{
+		Flat:                   &flat,
+		Date:                   date,
+		ScheduleIdentifierType: identifierType,
+		ScheduleIdentifier:     identifier,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nextOnCalls, err := client.GetNextOnCall(ctx, &schedule.GetNextOnCallsRequest{
+		Flat:                   &flat,
+		Date:                   date,
+		ScheduleIdentifierType: identifierType,
+		ScheduleIdentifier:     identifier,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(onCalls.Parent.Id)
+	d.Set("schedule_id", onCalls.Parent.Id)
+	d.Set("schedule_name", onCalls.Parent.Name)
+	d.Set("on_call_participants", flattenOpsgenieOnCallParticipants(onCalls.OnCallParticipants))
+	d.Set("on_call_recipients", onCalls.OnCallRecipients)
+	d.Set("next_on_call_recipients", flattenOpsgenieNextOnCallRecipients(nextOnCalls.NextOnCallRecipients))
+	d.Set("exact_next_on_call_recipients", flattenOpsgenieNextOnCallRecipients(nextOnCalls.ExactNextOnCallRecipients))
+	d.Set("next_on_call_participants", nextOnCalls.NextOncallParticipants)
+	d.Set("exact_next_on_call_participants", nextOnCalls.ExactNextOnCallParticipants)
+
+	return nil
+}
+
+func flattenOpsgenieOnCallParticipants(input []schedule.GetOnCallParticipant) []map[string]interface{} {
+	participants := make([]map[string]interface{}, 0, len(input))
+	for _, p := range input {
+		participant := make(map[string]interface{})
+		participant["id"] = p.Id
+		participant["name"] = p.Name
+		participant["type"] = string(p.Type)
+		participant["escalation_time"] = int(p.EscalationTime)
+		participant["notify_type"] = string(p.NotifyType)
+		participant["on_call_participants"] = flattenOpsgenieNestedOnCallParticipants(p.OnCallParticipants)
+		participants = append(participants, participant)
+	}
+	return participants
+}
+
+func flattenOpsgenieNestedOnCallParticipants(input []schedule.OnCallParticipant) []map[string]interface{} {
+	participants := make([]map[string]interface{}, 0, len(input))
+	for _, p := range input {
+		participant := make(map[string]interface{})
+		participant["id"] = p.Id
+		participant["name"] = p.Name
+		participant["type"] = string(p.Type)
+		participant["escalation_time"] = int(p.EscalationTime)
+		participant["notify_type"] = string(p.NotifyType)
+		participants = append(participants, participant)
+	}
+	return participants
+}
+
+func flattenOpsgenieNextOnCallRecipients(input []schedule.NextOnCallRecipients) []map[string]interface{} {
+	recipients := make([]map[string]interface{}, 0, len(input))
+	for _, r := range input {
+		recipient := make(map[string]interface{})
+		recipient["id"] = r.Id
+		recipient["name"] = r.Name
+		recipient["type"] = string(r.Type)
+		recipient["on_call_participants"] = flattenOpsgenieNestedOnCallParticipants(r.OnCallParticipants)
+		recipients = append(recipients, recipient)
+	}
+	return recipients
+}
@@ -0,0 +1,192 @@
+package opsgenie
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/schedule"
+)
+
+func dataSourceOpsgenieScheduleTimeline() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceOpsgenieScheduleTimelineRead,
+		Schema: map[string]*schema.Schema{
+			"schedule_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"schedule_id", "schedule_name"},
+			},
+			"schedule_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"schedule_id", "schedule_name"},
+			},
+			"date": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDate,
+			},
+			"interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"interval_unit": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "weeks",
+				ValidateFunc: validation.StringInSlice([]string{"days", "weeks", "months"}, false),
+			},
+			"expand": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"base", "forwarding", "override"}, false),
+				},
+			},
+			"start_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"end_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"final_timeline":      scheduleTimelineSchema(),
+			"base_timeline":       scheduleTimelineSchema(),
+			"override_timeline":   scheduleTimelineSchema(),
+			"forwarding_timeline": scheduleTimelineSchema(),
+		},
+	}
+}
+
+func scheduleTimelineSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"order": {
+					Type:     schema.TypeFloat,
+					Computed: true,
+				},
+				"periods": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"start_date": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"end_date": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"type": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"recipient_id": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"recipient_name": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"recipient_type": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOpsgenieScheduleTimelineRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).scheduleClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	getRequest := &schedule.GetTimelineRequest{
+		IdentifierType:  schedule.Id,
+		IdentifierValue: d.Get("schedule_id").(string),
+		Interval:        d.Get("interval").(int),
+		IntervalUnit:    schedule.Unit(d.Get("interval_unit").(string)),
+	}
+	if getRequest.IdentifierValue == "" {
+		getRequest.IdentifierType = schedule.Name
+		getRequest.IdentifierValue = d.Get("schedule_name").(string)
+	}
+	if v := d.Get("date").(string); v != "" {
+		date, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return diag.Errorf("Cannot parse date: %s", err)
+		}
+		getRequest.Date = &date
+	}
+	for _, v := range d.Get("expand").(*schema.Set).List() {
+		getRequest.Expands = append(getRequest.Expands, schedule.ExpandType(v.(string)))
+	}
+
+	getResponse, err := client.GetTimeline(ctx, getRequest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(getResponse.ScheduleInfo.Id)
+	d.Set("schedule_id", getResponse.ScheduleInfo.Id)
+	d.Set("schedule_name", getResponse.ScheduleInfo.Name)
+	d.Set("start_date", getResponse.StartDate.UTC().Format("2006-01-02T15:04:05Z"))
+	d.Set("end_date", getResponse.EndDate.UTC().Format("2006-01-02T15:04:05Z"))
+	d.Set("final_timeline", flattenOpsgenieScheduleTimeline(getResponse.FinalTimeline))
+	d.Set("base_timeline", flattenOpsgenieScheduleTimeline(getResponse.BaseTimeline))
+	d.Set("override_timeline", flattenOpsgenieScheduleTimeline(getResponse.OverrideTimeline))
+	d.Set("forwarding_timeline", flattenOpsgenieScheduleTimeline(getResponse.ForwardingTimeline))
+
+	return nil
+}
+
+func flattenOpsgenieScheduleTimeline(input schedule.Timeline) []map[string]interface{} {
+	rotations := make([]map[string]interface{}, 0, len(input.Rotations))
+	for _, r := range input.Rotations {
+		periods := make([]map[string]interface{}, 0, len(r.Periods))
+		for _, p := range r.Periods {
+			period := make(map[string]interface{})
+			period["start_date"] = p.StartDate.UTC().Format("2006-01-02T15:04:05Z")
+			period["end_date"] = p.EndDate.UTC().Format("2006-01-02T15:04:05Z")
+			period["type"] = p.Type
+			period["recipient_id"] = p.Recipient.Id
+			period["recipient_name"] = p.Recipient.Name
+			period["recipient_type"] = string(p.Recipient.Type)
+			periods = append(periods, period)
+		}
+		rotation := make(map[string]interface{})
+		rotation["id"] = r.Id
+		rotation["name"] = r.Name
+		rotation["order"] = float64(r.Order)
+		rotation["periods"] = periods
+		rotations = append(rotations, rotation)
+	}
+	return rotations
+}
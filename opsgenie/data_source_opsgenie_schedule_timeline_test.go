@@ -0,0 +1,75 @@
+package opsgenie
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceOpsGenieScheduleTimeline_Basic(t *testing.T) {
+	randomUser := acctest.RandString(6)
+	randomTeam := acctest.RandString(6)
+	randomSchedule := acctest.RandString(6)
+	randomRotation := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceOpsGenieScheduleTimelineConfig(randomUser, randomTeam, randomSchedule, randomRotation),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.opsgenie_schedule_timeline.test", "schedule_id", "opsgenie_schedule.test", "id"),
+					resource.TestCheckResourceAttr("data.opsgenie_schedule_timeline.test", "final_timeline.#", "1"),
+					resource.TestCheckResourceAttrPair("data.opsgenie_schedule_timeline.test", "final_timeline.0.id", "opsgenie_schedule_rotation.test", "id"),
+					resource.TestCheckResourceAttrPair("data.opsgenie_schedule_timeline.test", "final_timeline.0.periods.0.recipient_id", "opsgenie_user.test", "id"),
+					resource.TestCheckResourceAttr("data.opsgenie_schedule_timeline.test", "base_timeline.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceOpsGenieScheduleTimelineConfig(randomUser, randomTeam, randomSchedule, randomRotation string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_user" "test" {
+  username  = "genietest-%s@opsgenie.com"
+  full_name = "Acceptance Test User"
+  role      = "User"
+}
+
+resource "opsgenie_team" "test" {
+  name        = "genieteam-%s"
+  description = "This team deals with all the things"
+}
+
+resource "opsgenie_schedule" "test" {
+  name          = "genieschedule-%s"
+  description   = "schedule test"
+  timezone      = "Europe/Rome"
+  enabled       = true
+  owner_team_id = "${opsgenie_team.test.id}"
+}
+
+resource "opsgenie_schedule_rotation" "test" {
+  schedule_id = "${opsgenie_schedule.test.id}"
+  name        = "genierotation-%s"
+  start_date  = "2019-06-18T17:30:00Z"
+  type        = "weekly"
+  length      = 1
+  participant {
+    type = "user"
+    id   = "${opsgenie_user.test.id}"
+  }
+}
+
+data "opsgenie_schedule_timeline" "test" {
+  schedule_id   = opsgenie_schedule.test.id
+  interval      = 2
+  interval_unit = "weeks"
+  expand        = ["base"]
+  depends_on    = [opsgenie_schedule_rotation.test]
+}
+`, randomUser, randomTeam, randomSchedule, randomRotation)
+}
@@ -0,0 +1,106 @@
+package opsgenie
+
+import (
+	"context"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/schedule"
+)
+
+func dataSourceOpsgenieSchedules() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceOpsgenieSchedulesRead,
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"schedules": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timezone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"owner_team_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOpsgenieSchedulesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).scheduleClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	expand := false
+	result, err := client.List(ctx, &schedule.ListRequest{Expand: &expand})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var nameRegex *regexp.Regexp
+	if v := d.Get("name_regex").(string); v != "" {
+		nameRegex = regexp.MustCompile(v)
+	}
+
+	// Opsgenie lists the schedules in no particular order
+	sort.Slice(result.Schedule, func(i, j int) bool {
+		return result.Schedule[i].Name < result.Schedule[j].Name
+	})
+
+	ids := make([]string, 0, len(result.Schedule))
+	schedules := make([]map[string]interface{}, 0, len(result.Schedule))
+	for _, s := range result.Schedule {
+		if nameRegex != nil && !nameRegex.MatchString(s.Name) {
+			continue
+		}
+		ownerTeamId := ""
+		if s.OwnerTeam != nil {
+			ownerTeamId = s.OwnerTeam.Id
+		}
+		ids = append(ids, s.Id)
+		schedules = append(schedules, map[string]interface{}{
+			"id":            s.Id,
+			"name":          s.Name,
+			"description":   s.Description,
+			"timezone":      s.Timezone,
+			"enabled":       s.Enabled,
+			"owner_team_id": ownerTeamId,
+		})
+	}
+
+	d.SetId(listDataSourceId(ids))
+	d.Set("schedules", schedules)
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package opsgenie
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceOpsGenieSchedules_Basic(t *testing.T) {
+	randomTeam := acctest.RandString(6)
+	randomSchedule := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceOpsGenieSchedulesConfig(randomTeam, randomSchedule),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.opsgenie_schedules.test", "schedules.#", "1"),
+					resource.TestCheckResourceAttrPair("data.opsgenie_schedules.test", "schedules.0.id", "opsgenie_schedule.test", "id"),
+					resource.TestCheckResourceAttrPair("data.opsgenie_schedules.test", "schedules.0.owner_team_id", "opsgenie_team.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceOpsGenieSchedulesConfig(randomTeam, randomSchedule string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_team" "test" {
+  name        = "genieteam-%s"
+  description = "This team deals with all the things"
+}
+
+resource "opsgenie_schedule" "test" {
+  name          = "genieschedule-%s"
+  description   = "schedule test"
+  timezone      = "Europe/Rome"
+  owner_team_id = "${opsgenie_team.test.id}"
+}
+
+data "opsgenie_schedules" "test" {
+  name_regex = "^${opsgenie_schedule.test.name}$"
+}
+`, randomTeam, randomSchedule)
+}
@@ -3,15 +3,16 @@ package opsgenie
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/team"
 )
 
 func dataSourceOpsGenieTeam() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceOpsGenieTeamRead,
+		ReadContext: dataSourceOpsGenieTeamRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -48,10 +49,10 @@ func dataSourceOpsGenieTeam() *schema.Resource {
 	}
 }
 
-func dataSourceOpsGenieTeamRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := team.NewClient(meta.(*OpsgenieClient).client.Config)
+func dataSourceOpsGenieTeamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).teamClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	teamName := d.Get("name").(string)
 
@@ -60,9 +61,9 @@ func dataSourceOpsGenieTeamRead(d *schema.ResourceData, meta interface{}) error
 		IdentifierValue: teamName,
 	}
 
-	getResponse, err := client.Get(context.Background(), getRequest)
+	getResponse, err := client.Get(ctx, getRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(getResponse.Id)
 
@@ -0,0 +1,86 @@
+package opsgenie
+
+import (
+	"context"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/team"
+)
+
+func dataSourceOpsGenieTeams() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceOpsGenieTeamsRead,
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"teams": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOpsGenieTeamsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).teamClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	result, err := client.List(ctx, &team.ListTeamRequest{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var nameRegex *regexp.Regexp
+	if v := d.Get("name_regex").(string); v != "" {
+		nameRegex = regexp.MustCompile(v)
+	}
+
+	// Opsgenie lists the teams in no particular order
+	sort.Slice(result.Teams, func(i, j int) bool {
+		return result.Teams[i].Name < result.Teams[j].Name
+	})
+
+	ids := make([]string, 0, len(result.Teams))
+	teams := make([]map[string]interface{}, 0, len(result.Teams))
+	for _, t := range result.Teams {
+		if nameRegex != nil && !nameRegex.MatchString(t.Name) {
+			continue
+		}
+		ids = append(ids, t.Id)
+		teams = append(teams, map[string]interface{}{
+			"id":          t.Id,
+			"name":        t.Name,
+			"description": t.Description,
+		})
+	}
+
+	d.SetId(listDataSourceId(ids))
+	d.Set("teams", teams)
+
+	return nil
+}
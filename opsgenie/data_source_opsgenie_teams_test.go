@@ -0,0 +1,46 @@
+package opsgenie
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceOpsGenieTeams_Basic(t *testing.T) {
+	randomTeam := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceOpsGenieTeamsConfig(randomTeam),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.opsgenie_teams.test", "teams.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair("data.opsgenie_teams.test", "teams.*.id", "opsgenie_team.first", "id"),
+					resource.TestCheckTypeSetElemAttrPair("data.opsgenie_teams.test", "teams.*.id", "opsgenie_team.second", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceOpsGenieTeamsConfig(randomTeam string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_team" "first" {
+  name        = "genieteam-%[1]s-1"
+  description = "This team deals with all the things"
+}
+
+resource "opsgenie_team" "second" {
+  name        = "genieteam-%[1]s-2"
+  description = "This team deals with all the other things"
+}
+
+data "opsgenie_teams" "test" {
+  name_regex = "^genieteam-%[1]s-"
+  depends_on = [opsgenie_team.first, opsgenie_team.second]
+}
+`, randomTeam)
+}
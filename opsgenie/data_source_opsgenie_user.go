@@ -2,6 +2,7 @@ package opsgenie
 
 import (
 	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/user"
 	"log"
@@ -9,9 +10,9 @@ import (
 
 func dataSourceOpsGenieUser() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceOpsGenieUserRead,
+		ReadContext: dataSourceOpsGenieUserRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"username": {
@@ -43,20 +44,20 @@ func dataSourceOpsGenieUser() *schema.Resource {
 	}
 }
 
-func dataSourceOpsGenieUserRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := user.NewClient(meta.(*OpsgenieClient).client.Config)
+func dataSourceOpsGenieUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).userClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	username := d.Get("username").(string)
 
 	log.Printf("[INFO] Reading OpsGenie user '%s'", username)
 
-	usr, err := client.Get(context.Background(), &user.GetRequest{
+	usr, err := client.Get(ctx, &user.GetRequest{
 		Identifier: username,
 	})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(usr.Id)
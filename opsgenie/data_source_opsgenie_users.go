@@ -0,0 +1,169 @@
+package opsgenie
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/user"
+)
+
+// usersListPageSize is the maximum number of users Opsgenie returns per page.
+const usersListPageSize = 100
+
+func dataSourceOpsGenieUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceOpsGenieUsersRead,
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"sort": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"username", "role", "fullName", "fullName.raw", "verified", "blocked", "createdAt",
+				}, false),
+			},
+			"order": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"asc", "desc"}, false),
+			},
+			"offset": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"username": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"full_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timezone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"locale": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"blocked": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"verified": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOpsGenieUsersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).userClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var nameRegex *regexp.Regexp
+	if v := d.Get("name_regex").(string); v != "" {
+		nameRegex = regexp.MustCompile(v)
+	}
+
+	listRequest := &user.ListRequest{
+		Limit:  usersListPageSize,
+		Offset: d.Get("offset").(int),
+		Sort:   user.SortField(d.Get("sort").(string)),
+		Order:  user.Order(d.Get("order").(string)),
+		Query:  d.Get("query").(string),
+	}
+
+	users := make([]map[string]interface{}, 0)
+	for {
+		log.Printf("[INFO] Listing OpsGenie users from offset %d", listRequest.Offset)
+
+		result, err := client.List(ctx, listRequest)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, u := range result.Users {
+			if nameRegex != nil && !nameRegex.MatchString(u.Username) {
+				continue
+			}
+			role := ""
+			if u.Role != nil {
+				role = u.Role.RoleName
+			}
+			users = append(users, map[string]interface{}{
+				"id":        u.Id,
+				"username":  u.Username,
+				"full_name": u.FullName,
+				"role":      role,
+				"timezone":  u.TimeZone,
+				"locale":    u.Locale,
+				"blocked":   u.Blocked,
+				"verified":  u.Verified,
+				"tags":      u.Tags,
+			})
+		}
+
+		listRequest.Offset += len(result.Users)
+		if len(result.Users) == 0 || result.Paging.Next == "" || listRequest.Offset >= result.TotalCount {
+			break
+		}
+	}
+
+	// without an explicit sort Opsgenie lists the users in no particular order
+	if d.Get("sort").(string) == "" {
+		sort.Slice(users, func(i, j int) bool {
+			return users[i]["username"].(string) < users[j]["username"].(string)
+		})
+	}
+
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u["id"].(string))
+	}
+
+	d.SetId(listDataSourceId(ids))
+	d.Set("users", users)
+
+	return nil
+}
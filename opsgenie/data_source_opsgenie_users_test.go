@@ -0,0 +1,44 @@
+package opsgenie
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceOpsGenieUsers_Basic(t *testing.T) {
+	randomUser := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceOpsGenieUsersConfig(randomUser),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.opsgenie_users.test", "users.#", "1"),
+					resource.TestCheckResourceAttrPair("data.opsgenie_users.test", "users.0.id", "opsgenie_user.test", "id"),
+					resource.TestCheckResourceAttr("data.opsgenie_users.test", "users.0.role", "User"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceOpsGenieUsersConfig(randomUser string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_user" "test" {
+  username  = "genietest-%s@opsgenie.com"
+  full_name = "Acceptance Test User"
+  role      = "User"
+}
+
+data "opsgenie_users" "test" {
+  query      = "role:User"
+  sort       = "username"
+  order      = "asc"
+  name_regex = "^${replace(opsgenie_user.test.username, ".", "\\.")}$"
+}
+`, randomUser)
+}
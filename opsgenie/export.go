@@ -0,0 +1,393 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/policy"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/schedule"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/team"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/user"
+)
+
+// exportedResource is an Opsgenie entity read through the importer and the
+// read function of its resource, the same way "terraform import" does.
+type exportedResource struct {
+	resourceType string
+	name         string
+	importId     string
+	data         *schema.ResourceData
+}
+
+func (r *exportedResource) address() string {
+	return r.resourceType + "." + r.name
+}
+
+// exporter walks an Opsgenie account and collects the resources to export.
+type exporter struct {
+	provider  *schema.Provider
+	meta      *OpsgenieClient
+	resources []*exportedResource
+	names     map[string]bool
+	// references maps the ids of exported resources to their address, so
+	// attributes pointing at them are written as references.
+	references map[string]string
+}
+
+// Export writes Terraform configuration and import blocks for the teams,
+// users, schedules and rotations, escalations, routing rules, policies,
+// integrations and heartbeats of an Opsgenie account to dir. The provider is
+// configured from the environment, e.g. OPSGENIE_API_KEY and OPSGENIE_API_URL.
+func Export(ctx context.Context, dir string) error {
+	p := Provider()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{})); diags.HasError() {
+		return fmt.Errorf("configuring the provider: %s", exportDiagnosticsError(diags))
+	}
+
+	e := &exporter{
+		provider:   p,
+		meta:       p.Meta().(*OpsgenieClient),
+		names:      make(map[string]bool),
+		references: make(map[string]string),
+	}
+	steps := []func(context.Context) error{
+		e.exportTeams,
+		e.exportUsers,
+		e.exportSchedules,
+		e.exportEscalations,
+		e.exportAlertPolicies,
+		e.exportIntegrations,
+		e.exportHeartbeats,
+	}
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := e.write(dir); err != nil {
+		return err
+	}
+	log.Printf("[INFO] Exported %d OpsGenie resources to %s", len(e.resources), dir)
+	return nil
+}
+
+// add imports the entity with importId into a resource of resourceType.
+// Entities which disappear while exporting are skipped.
+func (e *exporter) add(ctx context.Context, resourceType, name, importId string, referable bool) error {
+	r := e.provider.ResourcesMap[resourceType]
+
+	d := r.Data(&terraform.InstanceState{ID: importId})
+	imported, err := r.Importer.StateContext(ctx, d, e.meta)
+	if err != nil {
+		return fmt.Errorf("importing %s %q: %s", resourceType, importId, err)
+	}
+
+	d = r.Data(imported[0].State())
+	if diags := r.ReadContext(ctx, d, e.meta); diags.HasError() {
+		return fmt.Errorf("reading %s %q: %s", resourceType, importId, exportDiagnosticsError(diags))
+	}
+	if d.Id() == "" {
+		log.Printf("[WARN] OpsGenie %s %q disappeared while exporting, skipping it", resourceType, importId)
+		return nil
+	}
+
+	resource := &exportedResource{
+		resourceType: resourceType,
+		name:         e.uniqueName(resourceType, name),
+		importId:     importId,
+		data:         d,
+	}
+	e.resources = append(e.resources, resource)
+	if referable {
+		e.references[d.Id()] = resource.address() + ".id"
+	}
+	return nil
+}
+
+func exportDiagnosticsError(diags diag.Diagnostics) string {
+	var errs []string
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			errs = append(errs, d.Summary)
+		}
+	}
+	return strings.Join(errs, ", ")
+}
+
+var exportNameInvalidChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// uniqueName turns a display name into a resource name which is unique for
+// the resource type.
+func (e *exporter) uniqueName(resourceType, displayName string) string {
+	name := strings.Trim(exportNameInvalidChars.ReplaceAllString(strings.ToLower(displayName), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	unique := name
+	for i := 2; e.names[resourceType+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	e.names[resourceType+"."+unique] = true
+	return unique
+}
+
+func (e *exporter) exportTeams(ctx context.Context) error {
+	client, err := e.meta.teamClient()
+	if err != nil {
+		return err
+	}
+	result, err := client.List(ctx, &team.ListTeamRequest{})
+	if err != nil {
+		return err
+	}
+
+	for _, t := range result.Teams {
+		if err := e.add(ctx, "opsgenie_team", t.Name, t.Id, true); err != nil {
+			return err
+		}
+
+		rules, err := client.ListRoutingRules(ctx, &team.ListRoutingRulesRequest{
+			TeamIdentifierType:  team.Id,
+			TeamIdentifierValue: t.Id,
+		})
+		if err != nil {
+			return err
+		}
+		for _, rule := range rules.RoutingRules {
+			if err := e.add(ctx, "opsgenie_team_routing_rule", t.Name+"_"+rule.Name, t.Id+"/"+rule.Id, false); err != nil {
+				return err
+			}
+		}
+
+		if err := e.exportTeamPolicies(ctx, t.Id, t.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportTeamPolicies(ctx context.Context, teamId, teamName string) error {
+	client, err := e.meta.policyClient()
+	if err != nil {
+		return err
+	}
+
+	alertPolicies, err := client.ListAlertPolicies(ctx, &policy.ListAlertPoliciesRequest{TeamId: teamId})
+	if err != nil {
+		return err
+	}
+	for _, p := range alertPolicies.Policies {
+		if err := e.add(ctx, "opsgenie_alert_policy", teamName+"_"+p.Name, teamId+"/"+p.Id, false); err != nil {
+			return err
+		}
+	}
+
+	notificationPolicies, err := client.ListNotificationPolicies(ctx, &policy.ListNotificationPoliciesRequest{TeamId: teamId})
+	if err != nil {
+		return err
+	}
+	for _, p := range notificationPolicies.Policies {
+		if err := e.add(ctx, "opsgenie_notification_policy", teamName+"_"+p.Name, teamId+"/"+p.Id, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportAlertPolicies exports the global alert policies, team policies are
+// exported along with their team.
+func (e *exporter) exportAlertPolicies(ctx context.Context) error {
+	client, err := e.meta.policyClient()
+	if err != nil {
+		return err
+	}
+	result, err := client.ListAlertPolicies(ctx, &policy.ListAlertPoliciesRequest{})
+	if err != nil {
+		return err
+	}
+	for _, p := range result.Policies {
+		if err := e.add(ctx, "opsgenie_alert_policy", p.Name, p.Id, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportUsers(ctx context.Context) error {
+	client, err := e.meta.userClient()
+	if err != nil {
+		return err
+	}
+
+	listRequest := &user.ListRequest{Limit: usersListPageSize}
+	for {
+		result, err := client.List(ctx, listRequest)
+		if err != nil {
+			return err
+		}
+		for _, u := range result.Users {
+			if err := e.add(ctx, "opsgenie_user", strings.SplitN(u.Username, "@", 2)[0], u.Id, true); err != nil {
+				return err
+			}
+		}
+
+		listRequest.Offset += len(result.Users)
+		if len(result.Users) == 0 || result.Paging.Next == "" || listRequest.Offset >= result.TotalCount {
+			return nil
+		}
+	}
+}
+
+func (e *exporter) exportSchedules(ctx context.Context) error {
+	client, err := e.meta.scheduleClient()
+	if err != nil {
+		return err
+	}
+	expand := false
+	result, err := client.List(ctx, &schedule.ListRequest{Expand: &expand})
+	if err != nil {
+		return err
+	}
+
+	for _, s := range result.Schedule {
+		if err := e.add(ctx, "opsgenie_schedule", s.Name, s.Id, true); err != nil {
+			return err
+		}
+
+		rotations, err := client.ListRotations(ctx, &schedule.ListRotationsRequest{
+			ScheduleIdentifierType:  schedule.Id,
+			ScheduleIdentifierValue: s.Id,
+		})
+		if err != nil {
+			return err
+		}
+		for _, rotation := range rotations.Rotations {
+			if err := e.add(ctx, "opsgenie_schedule_rotation", s.Name+"_"+rotation.Name, s.Id+"/"+rotation.Id, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportEscalations(ctx context.Context) error {
+	client, err := e.meta.escalationClient()
+	if err != nil {
+		return err
+	}
+	result, err := client.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, esc := range result.Escalations {
+		if err := e.add(ctx, "opsgenie_escalation", esc.Name, esc.Id, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportIntegrations(ctx context.Context) error {
+	client, err := e.meta.integrationClient()
+	if err != nil {
+		return err
+	}
+	result, err := client.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, i := range result.Integrations {
+		if err := e.add(ctx, "opsgenie_integration", i.Name, i.Id, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportHeartbeats(ctx context.Context) error {
+	client, err := e.meta.heartbeatClient()
+	if err != nil {
+		return err
+	}
+	result, err := client.List(ctx)
+	if err != nil {
+		return err
+	}
+	// heartbeats are identified by their name, so they are never referenced
+	// by id to avoid turning unrelated names into references
+	for _, h := range result.Heartbeats {
+		if err := e.add(ctx, "opsgenie_heartbeat", h.Name, h.Name, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write writes a file per resource type, an imports.tf with the import
+// blocks and a versions.tf requiring a Terraform version supporting them.
+func (e *exporter) write(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	byType := make(map[string][]*exportedResource)
+	var types []string
+	for _, r := range e.resources {
+		if _, ok := byType[r.resourceType]; !ok {
+			types = append(types, r.resourceType)
+		}
+		byType[r.resourceType] = append(byType[r.resourceType], r)
+	}
+	sort.Strings(types)
+
+	files := map[string]string{
+		"versions.tf": exportVersions,
+	}
+	var imports strings.Builder
+	for _, resourceType := range types {
+		var config strings.Builder
+		for i, r := range byType[resourceType] {
+			if i > 0 {
+				config.WriteString("\n")
+			}
+			fmt.Fprintf(&config, "resource %q %q {\n", r.resourceType, r.name)
+			e.writeBody(&config, e.provider.ResourcesMap[r.resourceType].Schema, r.data, r.data.Id(), 1)
+			config.WriteString("}\n")
+
+			if imports.Len() > 0 {
+				imports.WriteString("\n")
+			}
+			fmt.Fprintf(&imports, "import {\n  to = %s\n  id = %s\n}\n", r.address(), hclString(r.importId))
+		}
+		files[resourceType+".tf"] = config.String()
+	}
+	files["imports.tf"] = imports.String()
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const exportVersions = `terraform {
+  required_version = ">= 1.5.0"
+
+  required_providers {
+    opsgenie = {
+      source = "opsgenie/opsgenie"
+    }
+  }
+}
+`
@@ -0,0 +1,187 @@
+package opsgenie
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// writeBody writes the arguments of an exported resource. Only attributes
+// its read function has set are written, leaving out computed attributes and
+// values which are the default anyway.
+func (e *exporter) writeBody(w *strings.Builder, s map[string]*schema.Schema, d *schema.ResourceData, selfId string, depth int) {
+	state := d.State().Attributes
+	values := make(map[string]interface{})
+	for k := range s {
+		_, set := state[k]
+		_, setList := state[k+".#"]
+		_, setMap := state[k+".%"]
+		if set || setList || setMap {
+			values[k] = d.Get(k)
+		}
+	}
+	e.writeValues(w, s, values, selfId, depth)
+}
+
+// writeValues writes the attributes of a body aligned on their equals sign,
+// followed by its nested blocks, the way terraform fmt lays them out.
+func (e *exporter) writeValues(w *strings.Builder, s map[string]*schema.Schema, values map[string]interface{}, selfId string, depth int) {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	indent := strings.Repeat("  ", depth)
+	var attributes [][2]string
+	var blocks []string
+	width := 0
+	for _, k := range keys {
+		sch, ok := s[k]
+		if !ok || (!sch.Optional && !sch.Required) || sch.Deprecated != "" {
+			continue
+		}
+		v := values[k]
+		if set, ok := v.(*schema.Set); ok {
+			v = set.List()
+		}
+		if exportSkipValue(sch, v) {
+			continue
+		}
+
+		if elem, ok := sch.Elem.(*schema.Resource); ok {
+			for _, item := range v.([]interface{}) {
+				itemValues, _ := item.(map[string]interface{})
+				var block strings.Builder
+				fmt.Fprintf(&block, "%s%s {\n", indent, k)
+				e.writeValues(&block, elem.Schema, itemValues, selfId, depth+1)
+				fmt.Fprintf(&block, "%s}\n", indent)
+				blocks = append(blocks, block.String())
+			}
+			continue
+		}
+
+		attributes = append(attributes, [2]string{k, e.hclValue(k, sch, v, selfId, depth)})
+		if len(k) > width {
+			width = len(k)
+		}
+	}
+
+	for _, a := range attributes {
+		fmt.Fprintf(w, "%s%-*s = %s\n", indent, width, a[0], a[1])
+	}
+	for i, block := range blocks {
+		if i > 0 || len(attributes) > 0 {
+			w.WriteString("\n")
+		}
+		w.WriteString(block)
+	}
+}
+
+// hclValue returns v as an HCL expression. Ids of other exported resources
+// are written as references to them.
+func (e *exporter) hclValue(key string, s *schema.Schema, v interface{}, selfId string, depth int) string {
+	switch s.Type {
+	case schema.TypeList, schema.TypeSet:
+		elem, _ := s.Elem.(*schema.Schema)
+		if elem == nil {
+			elem = &schema.Schema{Type: schema.TypeString}
+		}
+		items := make([]string, 0)
+		for _, item := range v.([]interface{}) {
+			items = append(items, e.hclValue(strings.TrimSuffix(key, "s"), elem, item, selfId, depth))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case schema.TypeMap:
+		elem, _ := s.Elem.(*schema.Schema)
+		if elem == nil {
+			elem = &schema.Schema{Type: schema.TypeString}
+		}
+		m := v.(map[string]interface{})
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, k := range keys {
+			fmt.Fprintf(&b, "%s%s = %s\n", strings.Repeat("  ", depth+1), hclString(k), e.hclValue(k, elem, m[k], selfId, depth+1))
+		}
+		b.WriteString(strings.Repeat("  ", depth) + "}")
+		return b.String()
+	case schema.TypeString:
+		str := fmt.Sprint(v)
+		if key == "id" || strings.HasSuffix(key, "_id") {
+			if ref, ok := e.references[str]; ok && str != selfId {
+				return ref
+			}
+		}
+		return hclString(str)
+	case schema.TypeFloat:
+		return strconv.FormatFloat(v.(float64), 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// exportSkipValue reports whether v doesn't need to be written because it is
+// the default value of the attribute or an empty value Opsgenie didn't return.
+func exportSkipValue(s *schema.Schema, v interface{}) bool {
+	if s.Default != nil {
+		return fmt.Sprint(s.Default) == fmt.Sprint(v) || v == ""
+	}
+	if s.Required {
+		return false
+	}
+	switch value := v.(type) {
+	case nil:
+		return true
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	case string:
+		return value == ""
+	case bool:
+		return !value
+	case int:
+		return value == 0
+	case float64:
+		return value == 0
+	}
+	return false
+}
+
+// hclString returns s as a quoted HCL string, escaping template sequences so
+// the value is used literally.
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
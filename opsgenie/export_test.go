@@ -0,0 +1,95 @@
+package opsgenie
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opsgenie/opsgenie-go-sdk-v2/team"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/user"
+)
+
+func TestExport(t *testing.T) {
+	api, client := testFakeOpsgenieClient(t)
+	t.Setenv("OPSGENIE_API_KEY", api.apiKey)
+	t.Setenv("OPSGENIE_API_URL", api.Host())
+	ctx := context.Background()
+
+	userClient, _ := client.userClient()
+	createdUser, err := userClient.Create(ctx, &user.CreateRequest{
+		Username: "jane.doe@example.com",
+		FullName: "Jane Doe",
+		Role:     &user.UserRoleRequest{RoleName: "User"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	teamClient, _ := client.teamClient()
+	if _, err := teamClient.Create(ctx, &team.CreateTeamRequest{
+		Name:    "SRE Team",
+		Members: []team.Member{{User: team.User{ID: createdUser.Id}, Role: "admin"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := Export(ctx, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string][]string{
+		"opsgenie_team.tf": {
+			`resource "opsgenie_team" "sre_team" {`,
+			`    id       = opsgenie_user.jane_doe.id`,
+		},
+		"opsgenie_user.tf": {
+			`resource "opsgenie_user" "jane_doe" {`,
+			`  username  = "jane.doe@example.com"`,
+		},
+		"opsgenie_escalation.tf": {
+			`resource "opsgenie_escalation" "sre_team_escalation" {`,
+			`  owner_team_id = opsgenie_team.sre_team.id`,
+			`      id   = opsgenie_schedule.sre_team_schedule.id`,
+		},
+		"opsgenie_team_routing_rule.tf": {
+			`resource "opsgenie_team_routing_rule" "sre_team_default_routing_rule" {`,
+			`  team_id    = opsgenie_team.sre_team.id`,
+		},
+		"imports.tf": {
+			"import {\n  to = opsgenie_team.sre_team\n  id = \"",
+			"import {\n  to = opsgenie_user.jane_doe\n  id = \"" + createdUser.Id + "\"\n}",
+		},
+		"versions.tf": {
+			`required_version = ">= 1.5.0"`,
+		},
+	}
+	for file, lines := range expected {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range lines {
+			if !strings.Contains(string(content), line) {
+				t.Errorf("Expected %s to contain %q, got:\n%s", file, line, content)
+			}
+		}
+	}
+}
+
+func TestHclString(t *testing.T) {
+	values := map[string]string{
+		"plain":              `"plain"`,
+		`say "hi"`:           `"say \"hi\""`,
+		"a\\b":               `"a\\b"`,
+		"line\nbreak":        `"line\nbreak"`,
+		"${var.x} %{if x}":   `"$${var.x} %%{if x}"`,
+		"$ and % stay alone": `"$ and % stay alone"`,
+	}
+	for value, expected := range values {
+		if actual := hclString(value); actual != expected {
+			t.Errorf("Expected %q to be written as %s, got %s", value, expected, actual)
+		}
+	}
+}
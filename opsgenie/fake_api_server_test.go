@@ -0,0 +1,1105 @@
+package opsgenie
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/heartbeat"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/notification"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/team"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/user"
+)
+
+// fakeOpsgenieAPI is an in-memory implementation of the parts of the Opsgenie
+// REST API used by the provider. It lets the acceptance tests and sweepers run
+// without an Opsgenie account, see TestMain.
+type fakeOpsgenieAPI struct {
+	*httptest.Server
+
+	apiKey string
+
+	// processingDelay is how long a request stays unprocessed, during which
+	// its status can't be queried yet, like the asynchronous processing of
+	// requests by Opsgenie.
+	processingDelay time.Duration
+
+	mu          sync.Mutex
+	seq         int
+	collections map[string]*fakeCollection
+	documents   map[string]interface{}
+	requests    map[string]*fakeRequestStatus
+}
+
+// fakeCollection holds the entities of a collection path like /v2/teams or
+// /v2/teams/<team_id>/routing-rules in creation order.
+type fakeCollection struct {
+	keys     []string
+	entities map[string]map[string]interface{}
+}
+
+type fakeRequestStatus struct {
+	processedAt time.Time
+	action      string
+	entityId    string
+	success     bool
+	status      string
+}
+
+type fakeResponse struct {
+	status     int
+	result     string
+	message    string
+	data       interface{}
+	totalCount int
+}
+
+// fakeActions are the path segments following an entity which act on the
+// entity instead of naming a sub collection.
+var fakeActions = map[string]bool{
+	"enable":             true,
+	"disable":            true,
+	"cancel":             true,
+	"change-end-date":    true,
+	"change-order":       true,
+	"ping":               true,
+	"timeline":           true,
+	"on-calls":           true,
+	"next-on-calls":      true,
+	"actions":            true,
+	"audience-templates": true,
+	"teams":              true,
+	"schedules":          true,
+	"members":            true,
+}
+
+func newFakeOpsgenieAPI(apiKey string) *fakeOpsgenieAPI {
+	api := &fakeOpsgenieAPI{
+		apiKey:      apiKey,
+		collections: make(map[string]*fakeCollection),
+		documents:   make(map[string]interface{}),
+		requests:    make(map[string]*fakeRequestStatus),
+	}
+	api.Server = httptest.NewServer(api)
+	return api
+}
+
+// Host returns the value api_url has to be set to for the provider to send
+// its requests to the fake API.
+func (api *fakeOpsgenieAPI) Host() string {
+	return api.Listener.Addr().String()
+}
+
+func (api *fakeOpsgenieAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	requestId := api.nextId()
+	var resp fakeResponse
+	if r.Header.Get("Authorization") != "GenieKey "+api.apiKey {
+		resp = fakeError(http.StatusUnauthorized, "Could not authenticate")
+	} else {
+		body := make(map[string]interface{})
+		if r.Method != http.MethodGet && r.Method != http.MethodDelete {
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+				resp = fakeError(http.StatusBadRequest, "Could not parse request body: "+err.Error())
+			}
+		}
+		if resp.status == 0 {
+			resp = api.route(r.Method, r.URL.Path, r.URL.Query(), body)
+		}
+		if r.Method != http.MethodGet && resp.status != http.StatusNotFound {
+			status := &fakeRequestStatus{
+				processedAt: time.Now().Add(api.processingDelay),
+				action:      resp.result,
+				success:     resp.status < 400,
+				status:      resp.result,
+			}
+			if !status.success {
+				status.status = resp.message
+			}
+			if data, ok := resp.data.(map[string]interface{}); ok {
+				status.entityId, _ = data["id"].(string)
+			}
+			api.requests[requestId] = status
+		}
+	}
+
+	payload := map[string]interface{}{
+		"took":      0.01,
+		"requestId": requestId,
+	}
+	if resp.status >= 400 {
+		payload["message"] = resp.message
+	} else {
+		payload["result"] = resp.result
+		if resp.data != nil {
+			payload["data"] = resp.data
+		}
+		if list, ok := resp.data.([]map[string]interface{}); ok {
+			payload["totalCount"] = resp.totalCount
+			if resp.totalCount == 0 {
+				payload["totalCount"] = len(list)
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Request-Id", requestId)
+	w.Header().Set("X-Response-Time", "0.01")
+	w.Header().Set("X-RateLimit-State", "OK")
+	w.Header().Set("X-RateLimit-Reason", "OK")
+	w.Header().Set("X-RateLimit-Period-In-Sec", "60")
+	w.WriteHeader(resp.status)
+	json.NewEncoder(w).Encode(payload)
+}
+
+func (api *fakeOpsgenieAPI) nextId() string {
+	api.seq++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", api.seq)
+}
+
+func (api *fakeOpsgenieAPI) collection(path string) *fakeCollection {
+	c, ok := api.collections[path]
+	if !ok {
+		c = &fakeCollection{entities: make(map[string]map[string]interface{})}
+		api.collections[path] = c
+	}
+	return c
+}
+
+// lookup finds an entity by id or by any of the other identifiers Opsgenie
+// accepts in place of it, like names and usernames.
+func (c *fakeCollection) lookup(identifier string) (string, map[string]interface{}, bool) {
+	if e, ok := c.entities[identifier]; ok {
+		return identifier, e, true
+	}
+	for _, key := range c.keys {
+		e := c.entities[key]
+		for _, field := range []string{"name", "username", "alias"} {
+			if v, ok := e[field].(string); ok && v == identifier {
+				return key, e, true
+			}
+		}
+	}
+	return "", nil, false
+}
+
+func (c *fakeCollection) put(key string, entity map[string]interface{}) {
+	if _, ok := c.entities[key]; !ok {
+		c.keys = append(c.keys, key)
+	}
+	c.entities[key] = entity
+}
+
+func (c *fakeCollection) remove(key string) {
+	delete(c.entities, key)
+	for i, k := range c.keys {
+		if k == key {
+			c.keys = append(c.keys[:i], c.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// list returns the entities in creation order, or sorted by their order field
+// for ordered entities like routing rules and policies.
+func (c *fakeCollection) list() []map[string]interface{} {
+	list := make([]map[string]interface{}, 0, len(c.keys))
+	for _, key := range c.keys {
+		list = append(list, c.entities[key])
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		// the default routing rule always comes last
+		if di, dj := list[i]["isDefault"] == true, list[j]["isDefault"] == true; di != dj {
+			return dj
+		}
+		oi, iok := list[i]["order"].(float64)
+		oj, jok := list[j]["order"].(float64)
+		return iok && jok && oi < oj
+	})
+	return list
+}
+
+func fakeError(status int, message string) fakeResponse {
+	return fakeResponse{status: status, message: message}
+}
+
+func fakeNotFound(kind, identifier string) fakeResponse {
+	return fakeError(http.StatusNotFound, fmt.Sprintf("%s with identifier [%s] doesn't exist", kind, identifier))
+}
+
+func (api *fakeOpsgenieAPI) route(method, path string, query map[string][]string, body map[string]interface{}) fakeResponse {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 2 {
+		return fakeError(http.StatusNotFound, "No such endpoint")
+	}
+
+	if n := len(segments); n >= 3 && segments[n-2] == "requests" && method == http.MethodGet {
+		return api.requestStatus(segments[n-1])
+	}
+	// saved searches live below /v2/alerts but are a collection of their own
+	if len(segments) >= 3 && segments[1] == "alerts" && segments[2] == "saved-searches" {
+		segments = append([]string{segments[0], "saved-searches"}, segments[3:]...)
+	}
+	if n := len(segments); n >= 5 && segments[n-2] == "notification-rules" && segments[n-1] == "copy-to" && method == http.MethodPost {
+		return api.copyNotificationRules(segments[n-3], body)
+	}
+	if segments[1] == "policies" && len(segments) == 3 && (segments[2] == "alert" || segments[2] == "notification") {
+		return api.listPolicies(segments[2], firstValue(query, "teamId"))
+	}
+
+	path = "/" + segments[0]
+	rest := segments[1:]
+	for i := 0; i < len(rest); i += 2 {
+		path += "/" + rest[i]
+		if i+1 == len(rest) {
+			return api.handleCollection(method, path, query, body)
+		}
+
+		c := api.collection(path)
+		key, entity, ok := c.lookup(rest[i+1])
+		if !ok {
+			return fakeNotFound(fakeKind(rest[i]), rest[i+1])
+		}
+		switch {
+		case i+2 == len(rest):
+			return api.handleEntity(method, path, key, entity, body)
+		case fakeActions[rest[i+2]] && (i+3 == len(rest) || rest[i+2] == "members"):
+			return api.handleAction(method, path, key, entity, rest[i+2:], body)
+		}
+		path += "/" + key
+	}
+	return fakeError(http.StatusNotFound, "No such endpoint")
+}
+
+func (api *fakeOpsgenieAPI) handleCollection(method, path string, query map[string][]string, body map[string]interface{}) fakeResponse {
+	c := api.collection(path)
+	kind := fakeKind(path[strings.LastIndex(path, "/")+1:])
+
+	switch method {
+	case http.MethodGet:
+		list := c.list()
+		switch kind {
+		case "Incident template":
+			// the incident templates of services are listed as data itself
+			if !strings.HasPrefix(path, "/v1/services/") {
+				return fakeResponse{status: http.StatusOK, data: map[string]interface{}{"incidentTemplates": list}}
+			}
+		case "Heartbeat":
+			return fakeResponse{status: http.StatusOK, data: map[string]interface{}{"heartbeats": list}}
+		}
+		total := len(list)
+		offset, _ := strconv.Atoi(firstValue(query, "offset"))
+		limit, _ := strconv.Atoi(firstValue(query, "limit"))
+		if offset > len(list) {
+			offset = len(list)
+		}
+		list = list[offset:]
+		if limit > 0 && limit < len(list) {
+			list = list[:limit]
+		}
+		return fakeResponse{status: http.StatusOK, data: list, totalCount: total}
+	case http.MethodPost:
+		if err := api.validate(path, "", body); err != nil {
+			return fakeError(http.StatusUnprocessableEntity, err.Error())
+		}
+		if template, ok := body["incidentTemplate"].(map[string]interface{}); ok && kind == "Incident template" {
+			body = template
+		}
+		key := api.nextId()
+		switch kind {
+		case "Heartbeat":
+			key, _ = body["name"].(string)
+			if _, _, ok := c.lookup(key); ok {
+				return fakeError(http.StatusConflict, fmt.Sprintf("Heartbeat with name [%s] already exists", key))
+			}
+		case "Schedule override":
+			if alias, ok := body["alias"].(string); ok && alias != "" {
+				key = alias
+			}
+			body["alias"] = key
+		default:
+			body["id"] = key
+		}
+		if teamId := firstValue(query, "teamId"); teamId != "" {
+			body["teamId"] = teamId
+		}
+		api.created(path, kind, key, body)
+		fakeNormalize(kind, body)
+		c.put(key, body)
+		return fakeResponse{status: http.StatusCreated, result: "Created", data: fakeCopy(body)}
+	}
+	return fakeError(http.StatusMethodNotAllowed, "Method not allowed")
+}
+
+func (api *fakeOpsgenieAPI) handleEntity(method, path, key string, entity, body map[string]interface{}) fakeResponse {
+	c := api.collection(path)
+	kind := fakeKind(path[strings.LastIndex(path, "/")+1:])
+
+	switch method {
+	case http.MethodGet:
+		return fakeResponse{status: http.StatusOK, data: api.view(path, key, entity)}
+	case http.MethodPatch, http.MethodPut:
+		if err := api.validate(path, key, body); err != nil {
+			return fakeError(http.StatusUnprocessableEntity, err.Error())
+		}
+		if method == http.MethodPut {
+			// full updates replace everything except the fields Opsgenie manages
+			for _, field := range []string{"id", "alias", "type", "apiKey", "teamId", "emailAddress"} {
+				if _, ok := body[field]; !ok && entity[field] != nil {
+					body[field] = entity[field]
+				}
+			}
+			for k := range entity {
+				delete(entity, k)
+			}
+		}
+		for k, v := range body {
+			entity[k] = v
+		}
+		if kind == "Team" {
+			api.resolveTeamMembers(entity)
+		}
+		if kind == "Notification rule" {
+			api.putNotificationRuleSteps(path, key, entity)
+		}
+		api.resolveOwnerTeam(entity)
+		fakeNormalize(kind, entity)
+		return fakeResponse{status: http.StatusOK, result: "Updated", data: fakeCopy(entity)}
+	case http.MethodDelete:
+		if kind == "User" && api.userReferenced(key) {
+			return fakeError(http.StatusPreconditionRequired, "User is still referenced by teams or schedules")
+		}
+		c.remove(key)
+		for p := range api.collections {
+			if strings.HasPrefix(p, path+"/"+key+"/") {
+				delete(api.collections, p)
+			}
+		}
+		for doc := range api.documents {
+			if strings.HasPrefix(doc, path+"/"+key+"/") {
+				delete(api.documents, doc)
+			}
+		}
+		return fakeResponse{status: http.StatusOK, result: "Deleted"}
+	}
+	return fakeError(http.StatusMethodNotAllowed, "Method not allowed")
+}
+
+func (api *fakeOpsgenieAPI) handleAction(method, path, key string, entity map[string]interface{}, action []string, body map[string]interface{}) fakeResponse {
+	switch action[0] {
+	case "enable", "disable":
+		entity["enabled"] = action[0] == "enable"
+		if action[0] == "enable" {
+			return fakeResponse{status: http.StatusOK, result: "Enabled"}
+		}
+		return fakeResponse{status: http.StatusOK, result: "Disabled"}
+	case "cancel":
+		entity["status"] = "cancelled"
+		return fakeResponse{status: http.StatusOK, result: "Cancelled"}
+	case "change-end-date":
+		t, _ := entity["time"].(map[string]interface{})
+		if t == nil {
+			t = make(map[string]interface{})
+			entity["time"] = t
+		}
+		t["endDate"] = body["endDate"]
+		return fakeResponse{status: http.StatusOK, result: "Updated", data: fakeCopy(entity)}
+	case "change-order":
+		// routing rules are moved to their order, policies to their target
+		// index, and the siblings are renumbered
+		target, ok := body["order"].(float64)
+		if !ok {
+			target, _ = body["targetIndex"].(float64)
+		}
+		siblings := make([]map[string]interface{}, 0)
+		for _, sibling := range api.orderedSiblings(path, entity) {
+			if sibling["id"] != entity["id"] {
+				siblings = append(siblings, sibling)
+			}
+		}
+		index := int(target)
+		if index > len(siblings) {
+			index = len(siblings)
+		}
+		siblings = append(siblings[:index], append([]map[string]interface{}{entity}, siblings[index:]...)...)
+		for i, sibling := range siblings {
+			sibling["order"] = float64(i)
+		}
+		return fakeResponse{status: http.StatusOK, result: "Changed"}
+	case "ping":
+		entity["lastPingTime"] = time.Now().UTC().Format(time.RFC3339)
+		entity["expired"] = false
+		return fakeResponse{status: http.StatusAccepted, result: "PONG - Heartbeat received"}
+	case "actions":
+		doc := path + "/" + key + "/actions"
+		if method != http.MethodGet {
+			body["_parent"] = map[string]interface{}{"id": key, "name": entity["name"], "enabled": entity["enabled"], "type": entity["type"]}
+			api.documents[doc] = body
+		}
+		if _, ok := api.documents[doc]; !ok {
+			api.documents[doc] = map[string]interface{}{"_parent": map[string]interface{}{"id": key, "name": entity["name"], "enabled": entity["enabled"], "type": entity["type"]}}
+		}
+		return fakeResponse{status: http.StatusOK, result: "Updated", data: api.documents[doc]}
+	case "audience-templates":
+		// the lists of the responder and stakeholder left out of an update
+		// are kept
+		doc := path + "/" + key + "/audience-templates"
+		template, _ := api.documents[doc].(map[string]interface{})
+		if template == nil {
+			template = map[string]interface{}{
+				"responder":   map[string]interface{}{"teams": []interface{}{}, "individuals": []interface{}{}},
+				"stakeholder": map[string]interface{}{"individuals": []interface{}{}, "conditions": []interface{}{}},
+			}
+			api.documents[doc] = template
+		}
+		if method == http.MethodGet {
+			return fakeResponse{status: http.StatusOK, data: fakeCopy(template)}
+		}
+		for _, audience := range []string{"responder", "stakeholder"} {
+			update, _ := body[audience].(map[string]interface{})
+			for k, v := range update {
+				template[audience].(map[string]interface{})[k] = v
+			}
+		}
+		return fakeResponse{status: http.StatusOK, result: "Updated"}
+	case "members":
+		return api.handleTeamMembers(method, entity, action[1:], body)
+	case "teams":
+		return fakeResponse{status: http.StatusOK, data: api.userTeams(key)}
+	case "schedules":
+		return fakeResponse{status: http.StatusOK, data: api.userSchedules(key)}
+	case "on-calls", "next-on-calls":
+		return fakeResponse{status: http.StatusOK, data: api.scheduleOnCalls(key, entity)}
+	case "timeline":
+		return fakeResponse{status: http.StatusOK, data: api.scheduleTimeline(key, entity)}
+	}
+	return fakeError(http.StatusNotFound, "No such endpoint")
+}
+
+// requestStatus answers the request status API used to check the outcome of
+// asynchronously processed requests.
+func (api *fakeOpsgenieAPI) requestStatus(requestId string) fakeResponse {
+	status, ok := api.requests[requestId]
+	if !ok || time.Now().Before(status.processedAt) {
+		return fakeError(http.StatusNotFound, "Request not found. It might not be processed, yet.")
+	}
+	return fakeResponse{status: http.StatusOK, data: map[string]interface{}{
+		"success":     status.success,
+		"isSuccess":   status.success,
+		"action":      status.action,
+		"processedAt": status.processedAt.UTC().Format(time.RFC3339Nano),
+		"status":      status.status,
+		"entityId":    status.entityId,
+	}}
+}
+
+func (api *fakeOpsgenieAPI) listPolicies(policyType, teamId string) fakeResponse {
+	list := make([]map[string]interface{}, 0)
+	for _, p := range api.collection("/v2/policies").list() {
+		if p["type"] == policyType && (teamId == "" || p["teamId"] == teamId) {
+			list = append(list, p)
+		}
+	}
+	return fakeResponse{status: http.StatusOK, data: list}
+}
+
+// orderedSiblings returns the entities ordered along with the given routing
+// rule or policy, i.e. the other routing rules of the team but the default
+// one, or the policies of the same type and team.
+func (api *fakeOpsgenieAPI) orderedSiblings(path string, entity map[string]interface{}) []map[string]interface{} {
+	siblings := make([]map[string]interface{}, 0)
+	for _, e := range api.collection(path).list() {
+		if e["isDefault"] == true || e["type"] != entity["type"] || e["teamId"] != entity["teamId"] {
+			continue
+		}
+		siblings = append(siblings, e)
+	}
+	return siblings
+}
+
+// validate rejects requests missing the fields Opsgenie requires.
+func (api *fakeOpsgenieAPI) validate(path, key string, body map[string]interface{}) error {
+	required := map[string][]string{
+		"Team":         {"name"},
+		"User":         {"username", "fullName"},
+		"Schedule":     {"name"},
+		"Escalation":   {"name"},
+		"Heartbeat":    {"name"},
+		"Integration":  {"name"},
+		"Service":      {"name", "teamId"},
+		"Saved search": {"name", "query"},
+	}
+	kind := fakeKind(path[strings.LastIndex(path, "/")+1:])
+	for _, field := range required[kind] {
+		if v, ok := body[field]; key == "" && (!ok || v == "") {
+			return fmt.Errorf("%s can not be empty", field)
+		}
+	}
+	if kind == "Team" || kind == "Schedule" || kind == "Escalation" {
+		if name, ok := body["name"].(string); ok {
+			if k, _, exists := api.collection(path).lookup(name); exists && k != key {
+				return fmt.Errorf("%s with name [%s] already exists", kind, name)
+			}
+		}
+	}
+	return nil
+}
+
+// created applies the side effects Opsgenie has when an entity is created.
+func (api *fakeOpsgenieAPI) created(path, kind, key string, entity map[string]interface{}) {
+	api.resolveOwnerTeam(entity)
+
+	switch kind {
+	case "Team":
+		api.resolveTeamMembers(entity)
+		name, _ := entity["name"].(string)
+		owner := map[string]interface{}{"id": key, "name": name}
+
+		scheduleId := api.nextId()
+		api.collection("/v2/schedules").put(scheduleId, map[string]interface{}{
+			"id":        scheduleId,
+			"name":      name + "_schedule",
+			"timezone":  "America/New_York",
+			"enabled":   true,
+			"ownerTeam": owner,
+		})
+		escalationId := api.nextId()
+		api.collection("/v2/escalations").put(escalationId, map[string]interface{}{
+			"id":        escalationId,
+			"name":      name + "_escalation",
+			"ownerTeam": owner,
+			"rules": []interface{}{map[string]interface{}{
+				"condition":  "if-not-acked",
+				"notifyType": "default",
+				"delay":      map[string]interface{}{"timeAmount": 0, "timeUnit": "minutes"},
+				"recipient":  map[string]interface{}{"type": "schedule", "id": scheduleId, "name": name + "_schedule"},
+			}},
+		})
+		ruleId := api.nextId()
+		api.collection(path+"/"+key+"/routing-rules").put(ruleId, map[string]interface{}{
+			"id":        ruleId,
+			"name":      "Default Routing Rule",
+			"isDefault": true,
+			"order":     float64(0),
+			"criteria":  map[string]interface{}{"type": "match-all"},
+			"notify":    map[string]interface{}{"type": "escalation", "id": escalationId, "name": name + "_escalation"},
+		})
+	case "User":
+		entity["blocked"] = false
+		entity["verified"] = false
+		if _, ok := entity["role"]; !ok {
+			entity["role"] = map[string]interface{}{"id": "User", "name": "User"}
+		}
+	case "Integration":
+		entity["apiKey"] = api.nextId()
+		if _, ok := entity["enabled"]; !ok {
+			entity["enabled"] = false
+		}
+		if entity["type"] == "Email" {
+			username, _ := entity["emailUsername"].(string)
+			entity["emailAddress"] = username + "@fake.opsgenie.net"
+		}
+	case "Policy", "Routing rule":
+		// new policies and routing rules come after the existing ones
+		if _, ok := entity["order"].(float64); !ok && entity["isDefault"] != true {
+			entity["order"] = float64(len(api.orderedSiblings(path, entity)))
+		}
+	case "Heartbeat":
+		entity["expired"] = false
+	case "Notification rule":
+		api.putNotificationRuleSteps(path, key, entity)
+	case "Notification rule step":
+		if _, ok := entity["enabled"]; !ok {
+			entity["enabled"] = true
+		}
+	case "Contact":
+		entity["status"] = map[string]interface{}{"enabled": true}
+	case "Schedule":
+		if _, ok := entity["enabled"]; !ok {
+			entity["enabled"] = true
+		}
+	}
+}
+
+// copyNotificationRules replaces the notification rules of the given types of
+// the target users with copies of the rules of the user.
+func (api *fakeOpsgenieAPI) copyNotificationRules(identifier string, body map[string]interface{}) fakeResponse {
+	users := api.collection("/v2/users")
+	key, _, ok := users.lookup(identifier)
+	if !ok {
+		return fakeNotFound("User", identifier)
+	}
+	ruleTypes := make(map[string]bool)
+	types, _ := body["ruleTypes"].([]interface{})
+	for _, t := range types {
+		ruleTypes[fmt.Sprint(t)] = true
+	}
+	copied := func(rule map[string]interface{}) bool {
+		return notificationRuleCopied(ruleTypes, notification.ActionType(fmt.Sprint(rule["actionType"])))
+	}
+
+	path := "/v2/users/" + key + "/notification-rules"
+	toUsers, _ := body["toUsers"].([]interface{})
+	for _, u := range toUsers {
+		targetKey, _, ok := users.lookup(fmt.Sprint(u))
+		if !ok {
+			return fakeNotFound("User", fmt.Sprint(u))
+		}
+		targetPath := "/v2/users/" + targetKey + "/notification-rules"
+		target := api.collection(targetPath)
+		for _, rule := range target.list() {
+			if copied(rule) {
+				target.remove(rule["id"].(string))
+				delete(api.collections, targetPath+"/"+rule["id"].(string)+"/steps")
+			}
+		}
+		for _, rule := range api.collection(path).list() {
+			if !copied(rule) {
+				continue
+			}
+			ruleCopy := fakeCopy(rule)
+			ruleCopy["id"] = api.nextId()
+			steps := make([]interface{}, 0)
+			for _, step := range api.collection(path + "/" + rule["id"].(string) + "/steps").list() {
+				steps = append(steps, fakeCopy(step))
+			}
+			ruleCopy["steps"] = steps
+			api.putNotificationRuleSteps(targetPath, ruleCopy["id"].(string), ruleCopy)
+			target.put(ruleCopy["id"].(string), ruleCopy)
+		}
+	}
+	return fakeResponse{status: http.StatusOK, result: "Copied"}
+}
+
+// putNotificationRuleSteps moves the steps of a notification rule to its
+// steps collection, replacing the existing ones, the way a rule update sets
+// all of its steps.
+func (api *fakeOpsgenieAPI) putNotificationRuleSteps(path, key string, rule map[string]interface{}) {
+	steps, ok := rule["steps"].([]interface{})
+	if !ok {
+		return
+	}
+	delete(rule, "steps")
+	c := &fakeCollection{entities: make(map[string]map[string]interface{})}
+	for _, s := range steps {
+		step, _ := s.(map[string]interface{})
+		if step == nil {
+			continue
+		}
+		if _, ok := step["enabled"]; !ok {
+			step["enabled"] = true
+		}
+		step["id"] = api.nextId()
+		c.put(step["id"].(string), step)
+	}
+	api.collections[path+"/"+key+"/steps"] = c
+}
+
+// fakeNormalize drops the settings Opsgenie does not store, the way they are
+// left out of its responses.
+func fakeNormalize(kind string, entity map[string]interface{}) {
+	if kind == "Escalation" {
+		// a repeat without count and wait interval is not enabled
+		if repeat, ok := entity["repeat"].(map[string]interface{}); ok {
+			count, _ := repeat["count"].(float64)
+			waitInterval, _ := repeat["waitInterval"].(float64)
+			if count == 0 && waitInterval == 0 {
+				delete(entity, "repeat")
+			}
+		}
+	}
+}
+
+// view returns an entity the way Opsgenie shows it, filling in the fields
+// it computes on the fly.
+func (api *fakeOpsgenieAPI) view(path, key string, entity map[string]interface{}) map[string]interface{} {
+	data := fakeCopy(entity)
+	if strings.HasSuffix(path, "/maintenance") && data["status"] != "cancelled" {
+		data["status"] = "active"
+		if t, ok := data["time"].(map[string]interface{}); ok && t["type"] == "schedule" {
+			start, _ := time.Parse(time.RFC3339, fmt.Sprint(t["startDate"]))
+			end, _ := time.Parse(time.RFC3339, fmt.Sprint(t["endDate"]))
+			now := time.Now()
+			switch {
+			case now.Before(start):
+				data["status"] = "planned"
+			case now.After(end):
+				data["status"] = "past"
+			}
+		}
+	}
+	if strings.HasSuffix(path, "/notification-rules") {
+		data["steps"] = api.collection(path + "/" + key + "/steps").list()
+	}
+	if strings.HasSuffix(path, "/schedules") {
+		rotations := api.collection(path + "/" + key + "/rotations").list()
+		data["rotations"] = rotations
+	}
+	return data
+}
+
+func (api *fakeOpsgenieAPI) resolveOwnerTeam(entity map[string]interface{}) {
+	owner, ok := entity["ownerTeam"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	identifier, _ := owner["id"].(string)
+	if identifier == "" {
+		identifier, _ = owner["name"].(string)
+	}
+	if key, team, ok := api.collection("/v2/teams").lookup(identifier); ok {
+		entity["ownerTeam"] = map[string]interface{}{"id": key, "name": team["name"]}
+	}
+}
+
+func (api *fakeOpsgenieAPI) resolveTeamMembers(team map[string]interface{}) {
+	members, _ := team["members"].([]interface{})
+	for _, m := range members {
+		member, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, ok := member["role"]; !ok {
+			member["role"] = "user"
+		}
+		user, _ := member["user"].(map[string]interface{})
+		if user == nil {
+			continue
+		}
+		identifier, _ := user["id"].(string)
+		if identifier == "" {
+			identifier, _ = user["username"].(string)
+		}
+		if key, u, ok := api.collection("/v2/users").lookup(identifier); ok {
+			member["user"] = map[string]interface{}{"id": key, "username": u["username"]}
+		}
+	}
+}
+
+func (api *fakeOpsgenieAPI) handleTeamMembers(method string, team map[string]interface{}, rest []string, body map[string]interface{}) fakeResponse {
+	members, _ := team["members"].([]interface{})
+	switch {
+	case method == http.MethodPost && len(rest) == 0:
+		user, _ := body["user"].(map[string]interface{})
+		identifier := fmt.Sprint(user["id"])
+		if user["id"] == nil || user["id"] == "" {
+			identifier = fmt.Sprint(user["username"])
+		}
+		key, u, ok := api.collection("/v2/users").lookup(identifier)
+		if !ok {
+			return fakeNotFound("User", identifier)
+		}
+		role, _ := body["role"].(string)
+		if role == "" {
+			role = "user"
+		}
+		member := map[string]interface{}{
+			"user": map[string]interface{}{"id": key, "username": u["username"]},
+			"role": role,
+		}
+		for i, m := range members {
+			if fakeMemberIs(m, key) {
+				members[i] = member
+				return fakeResponse{status: http.StatusOK, result: "Added", data: map[string]interface{}{"id": team["id"]}}
+			}
+		}
+		team["members"] = append(members, member)
+		return fakeResponse{status: http.StatusOK, result: "Added", data: map[string]interface{}{"id": team["id"]}}
+	case method == http.MethodDelete && len(rest) == 1:
+		key, _, _ := api.collection("/v2/users").lookup(rest[0])
+		for i, m := range members {
+			if fakeMemberIs(m, key) || fakeMemberIs(m, rest[0]) {
+				team["members"] = append(members[:i], members[i+1:]...)
+				return fakeResponse{status: http.StatusOK, result: "Removed", data: map[string]interface{}{"id": team["id"]}}
+			}
+		}
+		return fakeNotFound("Member", rest[0])
+	}
+	return fakeError(http.StatusMethodNotAllowed, "Method not allowed")
+}
+
+func fakeMemberIs(m interface{}, identifier string) bool {
+	member, _ := m.(map[string]interface{})
+	user, _ := member["user"].(map[string]interface{})
+	return user != nil && identifier != "" && (user["id"] == identifier || user["username"] == identifier)
+}
+
+func (api *fakeOpsgenieAPI) userTeams(userId string) []map[string]interface{} {
+	teams := make([]map[string]interface{}, 0)
+	for _, t := range api.collection("/v2/teams").list() {
+		members, _ := t["members"].([]interface{})
+		for _, m := range members {
+			if fakeMemberIs(m, userId) {
+				teams = append(teams, map[string]interface{}{"id": t["id"], "name": t["name"]})
+				break
+			}
+		}
+	}
+	return teams
+}
+
+func (api *fakeOpsgenieAPI) userSchedules(userId string) []map[string]interface{} {
+	schedules := make([]map[string]interface{}, 0)
+	for _, s := range api.collection("/v2/schedules").list() {
+		if api.scheduleHasParticipant(s["id"].(string), userId) {
+			schedules = append(schedules, map[string]interface{}{"id": s["id"], "name": s["name"], "enabled": s["enabled"]})
+		}
+	}
+	return schedules
+}
+
+func (api *fakeOpsgenieAPI) scheduleHasParticipant(scheduleId, userId string) bool {
+	for _, r := range api.collection("/v2/schedules/" + scheduleId + "/rotations").list() {
+		participants, _ := r["participants"].([]interface{})
+		for _, p := range participants {
+			participant, _ := p.(map[string]interface{})
+			if participant["id"] == userId || participant["username"] == userId {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (api *fakeOpsgenieAPI) userReferenced(userId string) bool {
+	return len(api.userTeams(userId)) > 0 || len(api.userSchedules(userId)) > 0
+}
+
+// scheduleOnCalls treats the first participant of every rotation as on call.
+func (api *fakeOpsgenieAPI) scheduleOnCalls(key string, schedule map[string]interface{}) map[string]interface{} {
+	participants := make([]map[string]interface{}, 0)
+	recipients := make([]string, 0)
+	for _, r := range api.collection("/v2/schedules/" + key + "/rotations").list() {
+		rotationParticipants, _ := r["participants"].([]interface{})
+		if len(rotationParticipants) == 0 {
+			continue
+		}
+		p, _ := rotationParticipants[0].(map[string]interface{})
+		name := fmt.Sprint(p["id"])
+		if _, u, ok := api.collection("/v2/users").lookup(name); ok {
+			name = fmt.Sprint(u["username"])
+		}
+		participants = append(participants, map[string]interface{}{"id": p["id"], "name": name, "type": p["type"]})
+		recipients = append(recipients, name)
+	}
+	return map[string]interface{}{
+		"_parent":                   map[string]interface{}{"id": key, "name": schedule["name"], "enabled": schedule["enabled"]},
+		"onCallParticipants":        participants,
+		"onCallRecipients":          recipients,
+		"nextOnCallRecipients":      []interface{}{},
+		"exactNextOnCallRecipients": []interface{}{},
+	}
+}
+
+func (api *fakeOpsgenieAPI) scheduleTimeline(key string, schedule map[string]interface{}) map[string]interface{} {
+	start := time.Now().UTC().Truncate(24 * time.Hour)
+	end := start.Add(7 * 24 * time.Hour)
+	rotations := make([]map[string]interface{}, 0)
+	for i, r := range api.collection("/v2/schedules/" + key + "/rotations").list() {
+		periods := make([]map[string]interface{}, 0)
+		participants, _ := r["participants"].([]interface{})
+		for _, p := range participants {
+			participant, _ := p.(map[string]interface{})
+			periods = append(periods, map[string]interface{}{
+				"startDate": start.Format(time.RFC3339),
+				"endDate":   end.Format(time.RFC3339),
+				"type":      "default",
+				"recipient": map[string]interface{}{"id": participant["id"], "type": participant["type"]},
+			})
+			break
+		}
+		rotations = append(rotations, map[string]interface{}{"id": r["id"], "name": r["name"], "order": float64(i + 1), "periods": periods})
+	}
+	return map[string]interface{}{
+		"_parent":            map[string]interface{}{"id": key, "name": schedule["name"], "enabled": schedule["enabled"]},
+		"startDate":          start.Format(time.RFC3339),
+		"endDate":            end.Format(time.RFC3339),
+		"finalTimeline":      map[string]interface{}{"rotations": rotations},
+		"baseTimeline":       map[string]interface{}{"rotations": rotations},
+		"overrideTimeline":   map[string]interface{}{"rotations": []interface{}{}},
+		"forwardingTimeline": map[string]interface{}{"rotations": []interface{}{}},
+	}
+}
+
+// fakeKind names the entities of a collection path segment in error messages
+// and when a collection needs special handling.
+func fakeKind(collection string) string {
+	kinds := map[string]string{
+		"teams":              "Team",
+		"users":              "User",
+		"contacts":           "Contact",
+		"schedules":          "Schedule",
+		"rotations":          "Rotation",
+		"overrides":          "Schedule override",
+		"escalations":        "Escalation",
+		"integrations":       "Integration",
+		"policies":           "Policy",
+		"maintenance":        "Maintenance",
+		"heartbeats":         "Heartbeat",
+		"services":           "Service",
+		"incident-rules":     "Incident rule",
+		"incident-templates": "Incident template",
+		"routing-rules":      "Routing rule",
+		"roles":              "Role",
+		"notification-rules": "Notification rule",
+		"steps":              "Notification rule step",
+		"saved-searches":     "Saved search",
+	}
+	if kind, ok := kinds[collection]; ok {
+		return kind
+	}
+	return "Entity"
+}
+
+func fakeCopy(entity map[string]interface{}) map[string]interface{} {
+	data, _ := json.Marshal(entity)
+	copied := make(map[string]interface{})
+	json.Unmarshal(data, &copied)
+	return copied
+}
+
+func firstValue(values map[string][]string, key string) string {
+	if v := values[key]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func testFakeOpsgenieClient(t *testing.T) (*fakeOpsgenieAPI, *OpsgenieClient) {
+	api := newFakeOpsgenieAPI("fake-api-key")
+	t.Cleanup(api.Close)
+
+	config := Config{
+		ApiKey:            api.apiKey,
+		ApiUrl:            api.Host(),
+		ApiRetryCount:     1,
+		ApiRateLimitBurst: 1,
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return api, client
+}
+
+func TestFakeOpsgenieAPI_teamDefaultResources(t *testing.T) {
+	_, client := testFakeOpsgenieClient(t)
+	ctx := context.Background()
+
+	teamClient, _ := client.teamClient()
+	if _, err := teamClient.Create(ctx, &team.CreateTeamRequest{Name: "genieteam-fake"}); err != nil {
+		t.Fatal(err)
+	}
+	getResult, err := teamClient.Get(ctx, &team.GetTeamRequest{IdentifierType: team.Name, IdentifierValue: "genieteam-fake"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if getResult.Id == "" || getResult.Name != "genieteam-fake" {
+		t.Fatalf("Unexpected team %+v", getResult)
+	}
+
+	rules, err := teamClient.ListRoutingRules(ctx, &team.ListRoutingRulesRequest{TeamIdentifierType: team.Name, TeamIdentifierValue: "genieteam-fake"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules.RoutingRules) != 1 || !rules.RoutingRules[0].IsDefault {
+		t.Errorf("Expected the default routing rule, got %+v", rules.RoutingRules)
+	}
+	if err := findAndDeleteDefaultEscalation(ctx, "genieteam-fake", client); err != nil {
+		t.Error(err)
+	}
+	if err := findAndDeleteDefaultSchedule(ctx, "genieteam-fake", client); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := teamClient.Delete(ctx, &team.DeleteTeamRequest{IdentifierType: team.Id, IdentifierValue: getResult.Id}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = teamClient.Get(ctx, &team.GetTeamRequest{IdentifierType: team.Id, IdentifierValue: getResult.Id})
+	if apiErr, ok := err.(*ogClient.ApiError); !ok || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for a deleted team, got %v", err)
+	}
+}
+
+func TestFakeOpsgenieAPI_userReferencedByTeam(t *testing.T) {
+	_, client := testFakeOpsgenieClient(t)
+	ctx := context.Background()
+
+	userClient, _ := client.userClient()
+	teamClient, _ := client.teamClient()
+	created, err := userClient.Create(ctx, &user.CreateRequest{Username: "genietest-fake@opsgenie.com", FullName: "Fake User", Role: &user.UserRoleRequest{RoleName: "User"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = teamClient.Create(ctx, &team.CreateTeamRequest{
+		Name:    "genieteam-fake",
+		Members: []team.Member{{User: team.User{ID: created.Id}, Role: "admin"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = userClient.Delete(ctx, &user.DeleteRequest{Identifier: created.Id})
+	if apiErr, ok := err.(*ogClient.ApiError); !ok || apiErr.StatusCode != http.StatusPreconditionRequired {
+		t.Fatalf("Expected 428 while the user is a team member, got %v", err)
+	}
+
+	teams, err := userClient.ListUserTeams(ctx, &user.ListUserTeamsRequest{Identifier: created.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(teams.Teams) != 1 {
+		t.Fatalf("Expected the user to be in 1 team, got %d", len(teams.Teams))
+	}
+	if err := deleteUserFromTeams(ctx, userClient, created.Id, client); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := userClient.Delete(ctx, &user.DeleteRequest{Identifier: created.Id}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFakeOpsgenieAPI_requestStatus(t *testing.T) {
+	api, client := testFakeOpsgenieClient(t)
+	api.processingDelay = time.Hour
+	ctx := context.Background()
+
+	heartbeatClient, _ := client.heartbeatClient()
+	enabled := true
+	_, err := heartbeatClient.Add(ctx, &heartbeat.AddRequest{Name: "genietest-fake", Interval: 10, IntervalUnit: heartbeat.Minutes, Enabled: &enabled, AlertPriority: "P3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for requestId := range api.requests {
+		resp := api.requestStatus(requestId)
+		if resp.status != http.StatusNotFound {
+			t.Errorf("Request should not be processed before the processing delay, got %d", resp.status)
+		}
+		api.requests[requestId].processedAt = time.Now()
+		resp = api.requestStatus(requestId)
+		if resp.status != http.StatusOK || !resp.data.(map[string]interface{})["isSuccess"].(bool) {
+			t.Errorf("Expected a successful request status, got %+v", resp)
+		}
+	}
+
+	result, err := heartbeatClient.Get(ctx, "genietest-fake")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Name != "genietest-fake" || result.Interval != 10 {
+		t.Errorf("Unexpected heartbeat %+v", result)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
@@ -38,37 +39,74 @@ func Provider() *schema.Provider {
 				Optional: true,
 				Default:  -1,
 			},
+			"api_rate_limit": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"api_rate_limit_burst": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"api_rate_limit_domains": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeFloat},
+				ValidateFunc: validateRateLimitDomains,
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"opsgenie_custom_role":           resourceOpsGenieCustomUserRole(),
-			"opsgenie_team":                  resourceOpsGenieTeam(),
-			"opsgenie_team_routing_rule":     resourceOpsGenieTeamRoutingRule(),
-			"opsgenie_user":                  resourceOpsGenieUser(),
-			"opsgenie_user_contact":          resourceOpsGenieUserContact(),
-			"opsgenie_notification_policy":   resourceOpsGenieNotificationPolicy(),
-			"opsgenie_notification_rule":     resourceOpsGenieNotificationRule(),
-			"opsgenie_escalation":            resourceOpsgenieEscalation(),
-			"opsgenie_api_integration":       resourceOpsgenieApiIntegration(),
-			"opsgenie_email_integration":     resourceOpsgenieEmailIntegration(),
-			"opsgenie_integration_action":    resourceOpsgenieIntegrationAction(),
-			"opsgenie_service":               resourceOpsGenieService(),
-			"opsgenie_schedule":              resourceOpsgenieSchedule(),
-			"opsgenie_schedule_rotation":     resourceOpsgenieScheduleRotation(),
-			"opsgenie_maintenance":           resourceOpsgenieMaintenance(),
-			"opsgenie_heartbeat":             resourceOpsgenieHeartbeat(),
-			"opsgenie_alert_policy":          resourceOpsGenieAlertPolicy(),
-			"opsgenie_service_incident_rule": resourceOpsGenieServiceIncidentRule(),
-			"opsgenie_incident_template":     resourceOpsgenieIncidentTemplate(),
+			"opsgenie_custom_role":               resourceOpsGenieCustomUserRole(),
+			"opsgenie_team":                      resourceOpsGenieTeam(),
+			"opsgenie_team_routing_rule":         resourceOpsGenieTeamRoutingRule(),
+			"opsgenie_team_routing_rule_order":   resourceOpsGenieTeamRoutingRuleOrder(),
+			"opsgenie_team_membership":           resourceOpsGenieTeamMembership(),
+			"opsgenie_team_role":                 resourceOpsGenieTeamRole(),
+			"opsgenie_user":                      resourceOpsGenieUser(),
+			"opsgenie_user_contact":              resourceOpsGenieUserContact(),
+			"opsgenie_notification_policy":       resourceOpsGenieNotificationPolicy(),
+			"opsgenie_notification_policy_order": resourceOpsGenieNotificationPolicyOrder(),
+			"opsgenie_notification_rule":         resourceOpsGenieNotificationRule(),
+			"opsgenie_notification_rule_step":    resourceOpsGenieNotificationRuleStep(),
+			"opsgenie_notification_rule_copy":    resourceOpsGenieNotificationRuleCopy(),
+			"opsgenie_escalation":                resourceOpsgenieEscalation(),
+			"opsgenie_api_integration":           resourceOpsgenieApiIntegration(),
+			"opsgenie_email_integration":         resourceOpsgenieEmailIntegration(),
+			"opsgenie_integration":               resourceOpsgenieIntegration(),
+			"opsgenie_integration_action":        resourceOpsgenieIntegrationAction(),
+			"opsgenie_integration_single_action": resourceOpsgenieIntegrationSingleAction(),
+			"opsgenie_service":                   resourceOpsGenieService(),
+			"opsgenie_schedule":                  resourceOpsgenieSchedule(),
+			"opsgenie_schedule_rotation":         resourceOpsgenieScheduleRotation(),
+			"opsgenie_schedule_override":         resourceOpsgenieScheduleOverride(),
+			"opsgenie_maintenance":               resourceOpsgenieMaintenance(),
+			"opsgenie_maintenance_schedule":      resourceOpsgenieMaintenanceSchedule(),
+			"opsgenie_heartbeat":                 resourceOpsgenieHeartbeat(),
+			"opsgenie_alert_policy":              resourceOpsGenieAlertPolicy(),
+			"opsgenie_alert_policy_order":        resourceOpsGenieAlertPolicyOrder(),
+			"opsgenie_alert_saved_search":        resourceOpsGenieAlertSavedSearch(),
+			"opsgenie_service_incident_rule":     resourceOpsGenieServiceIncidentRule(),
+			"opsgenie_service_audience_template": resourceOpsGenieServiceAudienceTemplate(),
+			"opsgenie_service_incident_template": resourceOpsGenieServiceIncidentTemplate(),
+			"opsgenie_incident_template":         resourceOpsgenieIncidentTemplate(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"opsgenie_team":       dataSourceOpsGenieTeam(),
-			"opsgenie_user":       dataSourceOpsGenieUser(),
-			"opsgenie_escalation": dataSourceOpsgenieEscalation(),
-			"opsgenie_schedule":   dataSourceOpsgenieSchedule(),
-			"opsgenie_heartbeat":  dataSourceOpsgenieHeartbeat(),
-			"opsgenie_service":    dataSourceOpsGenieService(),
+			"opsgenie_team":              dataSourceOpsGenieTeam(),
+			"opsgenie_teams":             dataSourceOpsGenieTeams(),
+			"opsgenie_user":              dataSourceOpsGenieUser(),
+			"opsgenie_users":             dataSourceOpsGenieUsers(),
+			"opsgenie_escalation":        dataSourceOpsgenieEscalation(),
+			"opsgenie_schedule":          dataSourceOpsgenieSchedule(),
+			"opsgenie_schedule_on_calls": dataSourceOpsgenieScheduleOnCalls(),
+			"opsgenie_schedule_timeline": dataSourceOpsgenieScheduleTimeline(),
+			"opsgenie_schedules":         dataSourceOpsgenieSchedules(),
+			"opsgenie_heartbeat":         dataSourceOpsgenieHeartbeat(),
+			"opsgenie_service":           dataSourceOpsGenieService(),
 		},
 	}
 	p.ConfigureContextFunc = providerConfigure
@@ -86,6 +124,13 @@ func providerConfigure(ctx context.Context, data *schema.ResourceData) (interfac
 		ApiRetryCount:   data.Get("api_retry_count").(int),
 		ApiRetryWaitMin: data.Get("api_retry_wait_min").(int),
 		ApiRetryWaitMax: data.Get("api_retry_wait_max").(int),
+
+		ApiRateLimit:        data.Get("api_rate_limit").(float64),
+		ApiRateLimitBurst:   data.Get("api_rate_limit_burst").(int),
+		ApiRateLimitDomains: make(map[string]float64),
+	}
+	for domain, limit := range data.Get("api_rate_limit_domains").(map[string]interface{}) {
+		config.ApiRateLimitDomains[domain] = limit.(float64)
 	}
 	cli, err := config.Client()
 	if err != nil {
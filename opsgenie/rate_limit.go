@@ -0,0 +1,212 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Opsgenie applies its rate limits per API domain, so every domain gets its
+// own token bucket and a burst of alert calls can't starve configuration calls.
+const (
+	rateLimitDomainAlert         = "alert"
+	rateLimitDomainIncident      = "incident"
+	rateLimitDomainHeartbeat     = "heartbeat"
+	rateLimitDomainConfiguration = "configuration"
+)
+
+var rateLimitDomains = []string{
+	rateLimitDomainAlert,
+	rateLimitDomainIncident,
+	rateLimitDomainHeartbeat,
+	rateLimitDomainConfiguration,
+}
+
+// rateLimitDomain maps a request path to the Opsgenie rate limit domain it
+// is accounted against.
+func rateLimitDomain(path string) string {
+	path = strings.TrimPrefix(path, "/")
+	parts := strings.SplitN(path, "/", 3)
+	if len(parts) < 2 {
+		return rateLimitDomainConfiguration
+	}
+	switch parts[1] {
+	case "alerts":
+		return rateLimitDomainAlert
+	case "incidents":
+		return rateLimitDomainIncident
+	case "heartbeats":
+		return rateLimitDomainHeartbeat
+	default:
+		return rateLimitDomainConfiguration
+	}
+}
+
+// tokenBucket is a token bucket whose refill rate is lowered when Opsgenie
+// reports throttling and recovers step by step on successful responses.
+type tokenBucket struct {
+	mu          sync.Mutex
+	limit       float64
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func newTokenBucket(limit float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		limit:  limit,
+		rate:   limit,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait before
+// using it.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+	b.tokens--
+
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	if pause := b.pausedUntil.Sub(now); pause > delay {
+		delay = pause
+	}
+	return delay
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	delay := b.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// throttled halves the current rate, never going below a tenth of the
+// configured limit, and holds back new requests for retryAfter.
+func (b *tokenBucket) throttled(now time.Time, retryAfter time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rate = math.Max(b.rate/2, b.limit/10)
+	b.tokens = math.Min(b.tokens, 0)
+	if until := now.Add(retryAfter); until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+}
+
+// succeeded raises the current rate back towards the configured limit.
+func (b *tokenBucket) succeeded() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rate = math.Min(b.limit, b.rate+b.limit/20)
+}
+
+// rateLimitedTransport is an http.RoundTripper that waits for a token of the
+// request's domain before sending it and adapts to the rate limit headers
+// returned by Opsgenie.
+type rateLimitedTransport struct {
+	transport http.RoundTripper
+	buckets   map[string]*tokenBucket
+}
+
+func newRateLimitedTransport(transport http.RoundTripper, limit float64, burst int, domainLimits map[string]float64) *rateLimitedTransport {
+	t := &rateLimitedTransport{
+		transport: transport,
+		buckets:   make(map[string]*tokenBucket),
+	}
+	for _, domain := range rateLimitDomains {
+		domainLimit := limit
+		if v, ok := domainLimits[domain]; ok {
+			domainLimit = v
+		}
+		if domainLimit > 0 {
+			t.buckets[domain] = newTokenBucket(domainLimit, burst)
+		}
+	}
+	return t
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	domain := rateLimitDomain(req.URL.Path)
+	bucket, ok := t.buckets[domain]
+	if !ok {
+		return t.transport.RoundTrip(req)
+	}
+
+	if err := bucket.wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || strings.EqualFold(resp.Header.Get("X-RateLimit-State"), "THROTTLED") {
+		retryAfter := parseRetryAfter(resp.Header)
+		log.Printf("[DEBUG] OpsGenie throttled the %s API domain, slowing down for %s", domain, retryAfter)
+		bucket.throttled(time.Now(), retryAfter)
+	} else {
+		bucket.succeeded()
+	}
+
+	return resp, nil
+}
+
+// parseRetryAfter reads how long to hold back from the Retry-After header,
+// falling back to the X-RateLimit-Period-In-Sec header Opsgenie sends with
+// throttled responses. Both are given in seconds.
+func parseRetryAfter(header http.Header) time.Duration {
+	for _, name := range []string{"Retry-After", "X-RateLimit-Period-In-Sec"} {
+		seconds, err := strconv.Atoi(header.Get(name))
+		if err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return 0
+}
+
+func validateRateLimitDomains(v interface{}, k string) (ws []string, errors []error) {
+	for domain := range v.(map[string]interface{}) {
+		valid := false
+		for _, d := range rateLimitDomains {
+			if domain == d {
+				valid = true
+			}
+		}
+		if !valid {
+			errors = append(errors, fmt.Errorf("%q is not a rate limit domain, it can only be one of %s", domain, strings.Join(rateLimitDomains, ", ")))
+		}
+	}
+	return
+}
@@ -0,0 +1,97 @@
+package opsgenie
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimitDomain(t *testing.T) {
+	paths := map[string]string{
+		"/v2/alerts/123/notes":               rateLimitDomainAlert,
+		"/v1/incidents/create":               rateLimitDomainIncident,
+		"/v2/heartbeats/test/ping":           rateLimitDomainHeartbeat,
+		"/v2/teams/123/routing-rules":        rateLimitDomainConfiguration,
+		"/v1/incident-templates":             rateLimitDomainConfiguration,
+		"/v1/services/123/incident-rules/12": rateLimitDomainConfiguration,
+		"/":                                  rateLimitDomainConfiguration,
+	}
+	for path, expected := range paths {
+		if domain := rateLimitDomain(path); domain != expected {
+			t.Errorf("Expected domain of %s to be %s, got %s", path, expected, domain)
+		}
+	}
+}
+
+func TestTokenBucket_burst(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(2, 3)
+	bucket.last = now
+
+	for i := 0; i < 3; i++ {
+		if delay := bucket.reserve(now); delay != 0 {
+			t.Errorf("Request %d within the burst should not wait, got %s", i, delay)
+		}
+	}
+	if delay := bucket.reserve(now); delay != 500*time.Millisecond {
+		t.Errorf("Request after the burst should wait 500ms, got %s", delay)
+	}
+}
+
+func TestTokenBucket_throttled(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(10, 1)
+	bucket.last = now
+
+	bucket.throttled(now, 2*time.Second)
+	if bucket.rate != 5 {
+		t.Errorf("Rate should be halved after throttling, got %f", bucket.rate)
+	}
+	if delay := bucket.reserve(now); delay != 2*time.Second {
+		t.Errorf("Requests should be held back for Retry-After, got %s", delay)
+	}
+
+	for i := 0; i < 10; i++ {
+		bucket.throttled(now, 0)
+	}
+	if bucket.rate != 1 {
+		t.Errorf("Rate should not drop below a tenth of the limit, got %f", bucket.rate)
+	}
+
+	for i := 0; i < 100; i++ {
+		bucket.succeeded()
+	}
+	if bucket.rate != 10 {
+		t.Errorf("Rate should recover up to the limit, got %f", bucket.rate)
+	}
+}
+
+func TestRateLimitedTransport_throttledResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-State", "THROTTLED")
+		w.Header().Set("X-RateLimit-Period-In-Sec", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	transport := newRateLimitedTransport(http.DefaultTransport, 10, 10, map[string]float64{rateLimitDomainAlert: 0})
+	if _, ok := transport.buckets[rateLimitDomainAlert]; ok {
+		t.Errorf("Domain with a zero limit should not be rate limited")
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/v2/teams", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	bucket := transport.buckets[rateLimitDomainConfiguration]
+	if bucket.rate != 5 {
+		t.Errorf("Rate should be halved after a throttled response, got %f", bucket.rate)
+	}
+	if !bucket.pausedUntil.After(time.Now()) {
+		t.Errorf("Bucket should be paused for the rate limit period")
+	}
+}
@@ -22,8 +22,10 @@ func resourceOpsGenieAlertPolicy() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceOpsGenieAlertPolicyCreate,
 		ReadContext:   resourceOpsGenieAlertPolicyRead,
-		Update:        resourceOpsGenieAlertPolicyUpdate,
-		Delete:        resourceOpsGenieAlertPolicyDelete,
+		UpdateContext: resourceOpsGenieAlertPolicyUpdate,
+		DeleteContext: resourceOpsGenieAlertPolicyDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: validateConditionsDiff(conditionsBlock{path: []string{"filter"}, matchType: "type"}),
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idParts := strings.Split(d.Id(), "/")
@@ -69,53 +71,9 @@ func resourceOpsGenieAlertPolicy() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Default:      "match-all",
-							ValidateFunc: validation.StringInSlice([]string{"match-all", "match-any-condition", "match-all-conditions"}, false),
-						},
-						"conditions": {
-							Type:     schema.TypeSet,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"field": {
-										Type:     schema.TypeString,
-										Required: true,
-										ValidateFunc: validation.StringInSlice([]string{
-											"message", "alias", "description", "source", "entity", "tags",
-											"actions", "details", "extra-properties", "responders", "teams", "priority",
-										}, false),
-									},
-									"operation": {
-										Type:     schema.TypeString,
-										Required: true,
-										ValidateFunc: validation.StringInSlice([]string{
-											"matches", "contains", "starts-with", "ends-with", "equals", "contains-key",
-											"contains-value", "greater-than", "less-than", "is-empty", "equals-ignore-whitespace",
-										}, false),
-									},
-									"key": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "If 'field' is set as 'extra-properties', key could be used for key-value pair",
-									},
-									"not": {
-										Type:        schema.TypeBool,
-										Optional:    true,
-										Description: "Indicates behaviour of the given operation. Default value is false",
-										Default:     false,
-									},
-									"expected_value": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "User defined value that will be compared with alert field according to the operation. Default value is empty string",
-									},
-									"order": {
-										Type:        schema.TypeInt,
-										Optional:    true,
-										Description: "Order of the condition in conditions list",
-									},
-								},
-							},
+							ValidateFunc: validation.StringInSlice(conditionMatchTypes, false),
 						},
+						"conditions": conditionsSchema(schema.TypeSet, policyConditionFields),
 					},
 				},
 			},
@@ -218,7 +176,7 @@ func resourceOpsGenieAlertPolicy() *schema.Resource {
 }
 
 func resourceOpsGenieAlertPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, err := policy.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).policyClient()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -257,7 +215,7 @@ func resourceOpsGenieAlertPolicyCreate(ctx context.Context, d *schema.ResourceDa
 	}
 
 	log.Printf("[INFO] Creating Alert Policy '%s'", d.Get("name").(string))
-	result, err := client.CreateAlertPolicy(context.Background(), createRequest)
+	result, err := client.CreateAlertPolicy(ctx, createRequest)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -268,7 +226,7 @@ func resourceOpsGenieAlertPolicyCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceOpsGenieAlertPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, err := policy.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).policyClient()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -278,11 +236,11 @@ func resourceOpsGenieAlertPolicyRead(ctx context.Context, d *schema.ResourceData
 
 	policyRes := &policy.GetAlertPolicyResult{}
 	if d.Get("team_id").(string) == "" {
-		policyRes, err = client.GetAlertPolicy(context.Background(), &policy.GetAlertPolicyRequest{
+		policyRes, err = client.GetAlertPolicy(ctx, &policy.GetAlertPolicyRequest{
 			Id: d.Id(),
 		})
 	} else {
-		policyRes, err = client.GetAlertPolicy(context.Background(), &policy.GetAlertPolicyRequest{
+		policyRes, err = client.GetAlertPolicy(ctx, &policy.GetAlertPolicyRequest{
 			Id:     d.Id(),
 			TeamId: d.Get("team_id").(string),
 		})
@@ -335,10 +293,10 @@ func resourceOpsGenieAlertPolicyRead(ctx context.Context, d *schema.ResourceData
 	return nil
 }
 
-func resourceOpsGenieAlertPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := policy.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieAlertPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).policyClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	message := d.Get("message").(string)
@@ -376,19 +334,19 @@ func resourceOpsGenieAlertPolicyUpdate(d *schema.ResourceData, meta interface{})
 	}
 
 	log.Printf("[INFO] Updating Alert Policy '%s'", d.Get("name").(string))
-	_, err = client.UpdateAlertPolicy(context.Background(), updateRequest)
+	_, err = client.UpdateAlertPolicy(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceOpsGenieAlertPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsGenieAlertPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Deleting OpsGenie Alert Policy '%s'", d.Get("name").(string))
-	client, err := policy.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).policyClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	deleteRequest := &policy.DeletePolicyRequest{}
@@ -406,9 +364,9 @@ func resourceOpsGenieAlertPolicyDelete(d *schema.ResourceData, meta interface{})
 
 	}
 
-	_, err = client.DeletePolicy(context.Background(), deleteRequest)
+	_, err = client.DeletePolicy(ctx, deleteRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -469,33 +427,11 @@ func expandOpsGenieAlertPolicyFilter(input []interface{}) *og.Filter {
 	for _, v := range input {
 		config := v.(map[string]interface{})
 		filter.ConditionMatchType = og.ConditionMatchType(config["type"].(string))
-		filter.Conditions = expandOpsGenieAlertPolicyFilterConditions(config["conditions"].(*schema.Set))
+		filter.Conditions = expandOpsgenieConditions(config["conditions"].(*schema.Set).List())
 	}
 	return &filter
 }
 
-func expandOpsGenieAlertPolicyFilterConditions(input *schema.Set) []og.Condition {
-	conditions := make([]og.Condition, 0, input.Len())
-	condition := og.Condition{}
-	if input == nil {
-		return conditions
-	}
-
-	for _, v := range input.List() {
-		config := v.(map[string]interface{})
-		not_value := config["not"].(bool)
-		order := config["order"].(int)
-		condition.Field = og.ConditionFieldType(config["field"].(string))
-		condition.Operation = og.ConditionOperation(config["operation"].(string))
-		condition.Key = config["key"].(string)
-		condition.IsNot = &not_value
-		condition.ExpectedValue = config["expected_value"].(string)
-		condition.Order = &order
-		conditions = append(conditions, condition)
-	}
-	return conditions
-}
-
 func flattenOpsGenieAlertPolicyResponders(input *[]alert.Responder) []map[string]interface{} {
 	output := make([]map[string]interface{}, 0, len(*input))
 	for _, v := range *input {
@@ -514,7 +450,7 @@ func flattenOpsGenieAlertPolicyFilter(input *og.Filter) []map[string]interface{}
 	output := make([]map[string]interface{}, 0, 1)
 	element := make(map[string]interface{})
 	if input.Conditions != nil {
-		element["conditions"] = flattenOpsGenieAlertPolicyFilterConditions(input.Conditions)
+		element["conditions"] = flattenOpsgenieConditions(input.Conditions, true)
 	}
 	element["type"] = input.ConditionMatchType
 	output = append(output, element)
@@ -522,22 +458,6 @@ func flattenOpsGenieAlertPolicyFilter(input *og.Filter) []map[string]interface{}
 	return output
 }
 
-func flattenOpsGenieAlertPolicyFilterConditions(input []og.Condition) []map[string]interface{} {
-	output := make([]map[string]interface{}, 0, len(input))
-	for _, v := range input {
-		element := make(map[string]interface{})
-		element["field"] = v.Field
-		element["operation"] = v.Operation
-		element["key"] = v.Key
-		element["not"] = v.IsNot
-		element["expected_value"] = v.ExpectedValue
-		element["order"] = v.Order
-		output = append(output, element)
-	}
-
-	return output
-}
-
 func flattenOpsgenieAlertPolicyActions(d *schema.ResourceData) []string {
 	input := d.Get("actions").(*schema.Set)
 	actions := make([]string, len(input.List()))
@@ -0,0 +1,205 @@
+package opsgenie
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/alert"
+)
+
+func resourceOpsGenieAlertSavedSearch() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOpsGenieAlertSavedSearchCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsGenieAlertSavedSearchRead),
+		UpdateContext: resourceOpsGenieAlertSavedSearchUpdate,
+		DeleteContext: resourceOpsGenieAlertSavedSearchDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourceOpsGenieAlertSavedSearchCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateByIdOrName("saved search", lookupOpsgenieAlertSavedSearch(alert.ID), lookupOpsgenieAlertSavedSearch(alert.NAME)),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 100),
+			},
+			"query": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 1000),
+			},
+			"owner_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"teams": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				MaxItems: 20,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 15000),
+			},
+		},
+	}
+}
+
+// alertSavedSearchResult is a saved search including its owner, which the
+// GetSavedSearchResult of the SDK leaves out.
+type alertSavedSearchResult struct {
+	alert.GetSavedSearchResult
+	Owner alert.User `json:"owner,omitempty"`
+}
+
+// resourceOpsGenieAlertSavedSearchCustomizeDiff replaces saved searches whose
+// teams or description are removed. Opsgenie keeps the ones left out of an
+// update, so they can't be removed otherwise.
+func resourceOpsGenieAlertSavedSearchCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if old, new := d.GetChange("teams"); old.(*schema.Set).Len() > 0 && new.(*schema.Set).Len() == 0 {
+		if err := d.ForceNew("teams"); err != nil {
+			return err
+		}
+	}
+	if old, new := d.GetChange("description"); old.(string) != "" && new.(string) == "" {
+		if err := d.ForceNew("description"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceOpsGenieAlertSavedSearchCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).alertClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name := d.Get("name").(string)
+
+	createRequest := &alert.CreateSavedSearchRequest{
+		Name:        name,
+		Query:       d.Get("query").(string),
+		Owner:       alert.User{ID: d.Get("owner_id").(string)},
+		Description: d.Get("description").(string),
+		Teams:       expandOpsGenieAlertSavedSearchTeams(d.Get("teams").(*schema.Set)),
+	}
+
+	log.Printf("[INFO] Creating OpsGenie alert saved search '%s'", name)
+	result, err := client.CreateSavedSearch(ctx, createRequest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(result.Id)
+
+	return diag.FromErr(resourceOpsGenieAlertSavedSearchRead(ctx, d, meta))
+}
+
+func resourceOpsGenieAlertSavedSearchRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	result := &alertSavedSearchResult{}
+	err := meta.(*OpsgenieClient).client.Exec(ctx, &alert.GetSavedSearchRequest{
+		IdentifierType:  alert.ID,
+		IdentifierValue: d.Id(),
+	}, result)
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", result.Name)
+	d.Set("query", result.Query)
+	d.Set("description", result.Description)
+	d.Set("teams", flattenOpsGenieAlertSavedSearchTeams(result.Teams))
+	if result.Owner.ID != "" {
+		d.Set("owner_id", result.Owner.ID)
+	}
+
+	return nil
+}
+
+func resourceOpsGenieAlertSavedSearchUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).alertClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name := d.Get("name").(string)
+
+	updateRequest := &alert.UpdateSavedSearchRequest{
+		IdentifierType:  alert.ID,
+		IdentifierValue: d.Id(),
+		NewName:         name,
+		Query:           d.Get("query").(string),
+		Owner:           alert.User{ID: d.Get("owner_id").(string)},
+		Description:     d.Get("description").(string),
+		Teams:           expandOpsGenieAlertSavedSearchTeams(d.Get("teams").(*schema.Set)),
+	}
+
+	log.Printf("[INFO] Updating OpsGenie alert saved search '%s'", name)
+	_, err = client.UpdateSavedSearch(ctx, updateRequest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(resourceOpsGenieAlertSavedSearchRead(ctx, d, meta))
+}
+
+func resourceOpsGenieAlertSavedSearchDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).alertClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Deleting OpsGenie alert saved search '%s'", d.Get("name").(string))
+	_, err = client.DeleteSavedSearch(ctx, &alert.DeleteSavedSearchRequest{
+		IdentifierType:  alert.ID,
+		IdentifierValue: d.Id(),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func expandOpsGenieAlertSavedSearchTeams(input *schema.Set) []alert.Team {
+	teams := make([]alert.Team, 0, input.Len())
+	for _, v := range input.List() {
+		teams = append(teams, alert.Team{ID: v.(string)})
+	}
+	return teams
+}
+
+func flattenOpsGenieAlertSavedSearchTeams(input []alert.Team) []string {
+	teams := make([]string, 0, len(input))
+	for _, team := range input {
+		teams = append(teams, team.ID)
+	}
+	return teams
+}
+
+func lookupOpsgenieAlertSavedSearch(identifierType alert.SearchIdentifierType) importLookup {
+	return func(ctx context.Context, meta interface{}, identifier string) (string, error) {
+		client, err := meta.(*OpsgenieClient).alertClient()
+		if err != nil {
+			return "", err
+		}
+		result, err := client.GetSavedSearch(ctx, &alert.GetSavedSearchRequest{
+			IdentifierType:  identifierType,
+			IdentifierValue: identifier,
+		})
+		if err != nil {
+			return "", err
+		}
+		return result.Id, nil
+	}
+}
@@ -0,0 +1,119 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/alert"
+	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
+)
+
+func TestAccOpsGenieAlertSavedSearch_basic(t *testing.T) {
+	rs := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckOpsGenieAlertSavedSearchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpsGenieAlertSavedSearch_basic(rs, "status: open AND priority: P1"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieAlertSavedSearchExists("opsgenie_alert_saved_search.test"),
+					resource.TestCheckResourceAttr("opsgenie_alert_saved_search.test", "query", "status: open AND priority: P1"),
+					resource.TestCheckResourceAttr("opsgenie_alert_saved_search.test", "teams.#", "1"),
+					resource.TestCheckResourceAttrPair("opsgenie_alert_saved_search.test", "owner_id", "opsgenie_user.test", "id"),
+				),
+			},
+			{
+				Config: testAccOpsGenieAlertSavedSearch_basic(rs, "status: open"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieAlertSavedSearchExists("opsgenie_alert_saved_search.test"),
+					resource.TestCheckResourceAttr("opsgenie_alert_saved_search.test", "query", "status: open"),
+				),
+			},
+			{
+				ResourceName:      "opsgenie_alert_saved_search.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "opsgenie_alert_saved_search.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("genie-saved-search-%s", rs),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckOpsGenieAlertSavedSearchDestroy(s *terraform.State) error {
+	client, err := alert.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "opsgenie_alert_saved_search" {
+			continue
+		}
+		_, err := client.GetSavedSearch(context.Background(), &alert.GetSavedSearchRequest{
+			IdentifierType:  alert.ID,
+			IdentifierValue: rs.Primary.ID,
+		})
+		if err == nil {
+			return fmt.Errorf("Alert saved search %s still exists", rs.Primary.ID)
+		}
+		if apiErr, ok := err.(*ogClient.ApiError); !ok || apiErr.StatusCode != 404 {
+			return fmt.Errorf("Alert saved search %s still exists: %s", rs.Primary.ID, err)
+		}
+	}
+	return nil
+}
+
+func testCheckOpsGenieAlertSavedSearchExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		client, err := alert.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+		if err != nil {
+			return err
+		}
+		_, err = client.GetSavedSearch(context.Background(), &alert.GetSavedSearchRequest{
+			IdentifierType:  alert.ID,
+			IdentifierValue: rs.Primary.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("Bad: Alert saved search %q does not exist", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccOpsGenieAlertSavedSearch_basic(randomName, query string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_user" "test" {
+  username  = "genietest-%s@opsgenie.com"
+  full_name = "Test User"
+  role      = "User"
+}
+
+resource "opsgenie_team" "test" {
+  name        = "genieteam-%s"
+  description = "This team deals with all the things"
+}
+
+resource "opsgenie_alert_saved_search" "test" {
+  name        = "genie-saved-search-%s"
+  query       = %q
+  owner_id    = opsgenie_user.test.id
+  teams       = [opsgenie_team.test.id]
+  description = "Open alerts of the team"
+}
+`, randomName, randomName, randomName, query)
+}
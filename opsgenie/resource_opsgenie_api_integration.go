@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/integration"
@@ -12,12 +13,13 @@ import (
 
 func resourceOpsgenieApiIntegration() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsgenieApiIntegrationCreate,
-		Read:   handleNonExistentResource(resourceOpsgenieApiIntegrationRead),
-		Update: resourceOpsgenieApiIntegrationUpdate,
-		Delete: resourceOpsgenieApiIntegrationDelete,
+		CreateContext: resourceOpsgenieApiIntegrationCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsgenieApiIntegrationRead),
+		UpdateContext: resourceOpsgenieApiIntegrationUpdate,
+		DeleteContext: resourceOpsgenieApiIntegrationDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -95,12 +97,12 @@ func resourceOpsgenieApiIntegration() *schema.Resource {
 	}
 }
 
-func resourceOpsgenieApiIntegrationCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsgenieApiIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	integrationType := d.Get("type").(string)
 	if integrationType == WebhookIntegrationType {
-		return createWebhookIntegration(d, meta)
+		return diag.FromErr(createWebhookIntegration(ctx, d, meta))
 	}
-	return createApiIntegration(d, meta)
+	return diag.FromErr(createApiIntegration(ctx, d, meta))
 }
 
 func expandOpsGenieWebhookHeaders(d *schema.ResourceData) map[string]string {
@@ -118,8 +120,8 @@ func expandOpsGenieWebhookHeaders(d *schema.ResourceData) map[string]string {
 	return output
 }
 
-func createApiIntegration(d *schema.ResourceData, meta interface{}) error {
-	client, err := integration.NewClient(meta.(*OpsgenieClient).client.Config)
+func createApiIntegration(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).integrationClient()
 	if err != nil {
 		return err
 	}
@@ -158,7 +160,7 @@ func createApiIntegration(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[INFO] Creating OpsGenie api integration '%s'", name)
 
-	result, err := client.CreateApiBased(context.Background(), createRequest)
+	result, err := client.CreateApiBased(ctx, createRequest)
 	if err != nil {
 		return err
 	}
@@ -167,7 +169,7 @@ func createApiIntegration(d *schema.ResourceData, meta interface{}) error {
 	d.Set("api_key", result.ApiKey)
 
 	if enabled {
-		_, err = client.Enable(context.Background(), &integration.EnableIntegrationRequest{
+		_, err = client.Enable(ctx, &integration.EnableIntegrationRequest{
 			Id: result.Id,
 		})
 		if err != nil {
@@ -177,11 +179,11 @@ func createApiIntegration(d *schema.ResourceData, meta interface{}) error {
 
 	}
 
-	return resourceOpsgenieApiIntegrationRead(d, meta)
+	return resourceOpsgenieApiIntegrationRead(ctx, d, meta)
 }
 
-func createWebhookIntegration(d *schema.ResourceData, meta interface{}) error {
-	client, err := integration.NewClient(meta.(*OpsgenieClient).client.Config)
+func createWebhookIntegration(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).integrationClient()
 	if err != nil {
 		return err
 	}
@@ -214,7 +216,7 @@ func createWebhookIntegration(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[INFO] Creating OpsGenie Webhook integration '%s'", name)
 
-	result, err := client.CreateWebhook(context.Background(), createRequest)
+	result, err := client.CreateWebhook(ctx, createRequest)
 	if err != nil {
 		return err
 	}
@@ -223,7 +225,7 @@ func createWebhookIntegration(d *schema.ResourceData, meta interface{}) error {
 	d.Set("api_key", result.ApiKey)
 
 	if enabled {
-		_, err = client.Enable(context.Background(), &integration.EnableIntegrationRequest{
+		_, err = client.Enable(ctx, &integration.EnableIntegrationRequest{
 			Id: result.Id,
 		})
 		if err != nil {
@@ -232,16 +234,16 @@ func createWebhookIntegration(d *schema.ResourceData, meta interface{}) error {
 		log.Printf("[INFO] Enabled OpsGenie Webhook integration '%s'", name)
 	}
 
-	return resourceOpsgenieApiIntegrationRead(d, meta)
+	return resourceOpsgenieApiIntegrationRead(ctx, d, meta)
 }
 
-func resourceOpsgenieApiIntegrationRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := integration.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieApiIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).integrationClient()
 	if err != nil {
 		return err
 	}
 
-	result, err := client.Get(context.Background(), &integration.GetRequest{
+	result, err := client.Get(ctx, &integration.GetRequest{
 		Id: d.Id(),
 	})
 	if err != nil {
@@ -271,19 +273,19 @@ func resourceOpsgenieApiIntegrationRead(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
-func resourceOpsgenieApiIntegrationUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := integration.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieApiIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).integrationClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// GET+PUT workaround since the Opsgenie Integration API does not support HTTP PATCH method
-	result, err := client.Get(context.Background(), &integration.GetRequest{
+	result, err := client.Get(ctx, &integration.GetRequest{
 		Id: d.Id(),
 	})
 	if err != nil {
 		log.Printf("Error occurred while performing GET for integration: %s", d.Id())
-		return err
+		return diag.FromErr(err)
 	}
 	userProperties := result.Data
 	userProperties["allowWriteAccess"] = d.Get("allow_write_access")
@@ -335,27 +337,27 @@ func resourceOpsgenieApiIntegrationUpdate(d *schema.ResourceData, meta interface
 
 	log.Printf("[INFO] Updating OpsGenie api based integration '%s'", name)
 
-	_, err = client.ForceUpdateAllFields(context.Background(), updateRequest)
+	_, err = client.ForceUpdateAllFields(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceOpsgenieApiIntegrationDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsgenieApiIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Deleting OpsGenie api integration '%s'", d.Get("name").(string))
-	client, err := integration.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).integrationClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	deleteRequest := &integration.DeleteIntegrationRequest{
 		Id: d.Id(),
 	}
 
-	_, err = client.Delete(context.Background(), deleteRequest)
+	_, err = client.Delete(ctx, deleteRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
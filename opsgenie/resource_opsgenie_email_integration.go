@@ -6,6 +6,7 @@ import (
 
 	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/integration"
@@ -13,12 +14,13 @@ import (
 
 func resourceOpsgenieEmailIntegration() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsgenieEmailIntegrationCreate,
-		Read:   handleNonExistentResource(resourceOpsgenieEmailIntegrationRead),
-		Update: resourceOpsgenieEmailIntegrationUpdate,
-		Delete: resourceOpsgenieEmailIntegrationDelete,
+		CreateContext: resourceOpsgenieEmailIntegrationCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsgenieEmailIntegrationRead),
+		UpdateContext: resourceOpsgenieEmailIntegrationUpdate,
+		DeleteContext: resourceOpsgenieEmailIntegrationDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -68,10 +70,10 @@ func resourceOpsgenieEmailIntegration() *schema.Resource {
 	}
 }
 
-func resourceOpsgenieEmailIntegrationCreate(d *schema.ResourceData, meta interface{}) error {
-	client, err := integration.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieEmailIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).integrationClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	name := d.Get("name").(string)
 	emailUsername := d.Get("email_username").(string)
@@ -97,34 +99,34 @@ func resourceOpsgenieEmailIntegrationCreate(d *schema.ResourceData, meta interfa
 
 	log.Printf("[INFO] Creating OpsGenie email integration '%s'", name)
 
-	result, err := client.CreateEmailBased(context.Background(), createRequest)
+	result, err := client.CreateEmailBased(ctx, createRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(result.Id)
 
 	if enabled {
-		_, err = client.Enable(context.Background(), &integration.EnableIntegrationRequest{
+		_, err = client.Enable(ctx, &integration.EnableIntegrationRequest{
 			Id: result.Id,
 		})
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		log.Printf("[INFO] Enabled OpsGenie email integration '%s'", name)
 
 	}
 
-	return resourceOpsgenieEmailIntegrationRead(d, meta)
+	return diag.FromErr(resourceOpsgenieEmailIntegrationRead(ctx, d, meta))
 }
 
-func resourceOpsgenieEmailIntegrationRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := integration.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieEmailIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).integrationClient()
 	if err != nil {
 		return err
 	}
 
-	result, err := client.Get(context.Background(), &integration.GetRequest{
+	result, err := client.Get(ctx, &integration.GetRequest{
 		Id: d.Id(),
 	})
 	if err != nil {
@@ -144,10 +146,10 @@ func resourceOpsgenieEmailIntegrationRead(d *schema.ResourceData, meta interface
 	return nil
 }
 
-func resourceOpsgenieEmailIntegrationUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := integration.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieEmailIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).integrationClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	name := d.Get("name").(string)
 	emailUsername := d.Get("email_username").(string)
@@ -168,27 +170,27 @@ func resourceOpsgenieEmailIntegrationUpdate(d *schema.ResourceData, meta interfa
 
 	log.Printf("[INFO] Updating OpsGenie email based integration '%s'", name)
 
-	_, err = client.ForceUpdateAllFields(context.Background(), updateRequest)
+	_, err = client.ForceUpdateAllFields(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceOpsgenieEmailIntegrationDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsgenieEmailIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Deleting OpsGenie email integration '%s'", d.Get("name").(string))
-	client, err := integration.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).integrationClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	deleteRequest := &integration.DeleteIntegrationRequest{
 		Id: d.Id(),
 	}
 
-	_, err = client.Delete(context.Background(), deleteRequest)
+	_, err = client.Delete(ctx, deleteRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -8,18 +8,20 @@ import (
 
 	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/escalation"
 )
 
 func resourceOpsgenieEscalation() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsgenieEscalationCreate,
-		Read:   handleNonExistentResource(resourceOpsgenieEscalationRead),
-		Update: resourceOpsgenieEscalationUpdate,
-		Delete: resourceOpsgenieEscalationDelete,
+		CreateContext: resourceOpsgenieEscalationCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsgenieEscalationRead),
+		UpdateContext: resourceOpsgenieEscalationUpdate,
+		DeleteContext: resourceOpsgenieEscalationDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importStateByIdOrName("escalation", lookupOpsgenieEscalation(escalation.Id), lookupOpsgenieEscalation(escalation.Name)),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -101,10 +103,10 @@ func resourceOpsgenieEscalation() *schema.Resource {
 	}
 }
 
-func resourceOpsgenieEscalationCreate(d *schema.ResourceData, meta interface{}) error {
-	client, err := escalation.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieEscalationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).escalationClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -125,18 +127,18 @@ func resourceOpsgenieEscalationCreate(d *schema.ResourceData, meta interface{})
 
 	log.Printf("[INFO] Creating OpsGenie escalation '%s'", name)
 
-	result, err := client.Create(context.Background(), createRequest)
+	result, err := client.Create(ctx, createRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(result.Id)
 
-	return resourceOpsgenieEscalationRead(d, meta)
+	return diag.FromErr(resourceOpsgenieEscalationRead(ctx, d, meta))
 }
 
-func resourceOpsgenieEscalationRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := escalation.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieEscalationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).escalationClient()
 	if err != nil {
 		return err
 	}
@@ -146,7 +148,7 @@ func resourceOpsgenieEscalationRead(d *schema.ResourceData, meta interface{}) er
 		Identifier:     d.Id(),
 	}
 
-	getResponse, err := client.Get(context.Background(), getRequest)
+	getResponse, err := client.Get(ctx, getRequest)
 	if err != nil {
 		return err
 	}
@@ -164,10 +166,10 @@ func resourceOpsgenieEscalationRead(d *schema.ResourceData, meta interface{}) er
 	return nil
 }
 
-func resourceOpsgenieEscalationUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := escalation.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieEscalationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).escalationClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -190,28 +192,28 @@ func resourceOpsgenieEscalationUpdate(d *schema.ResourceData, meta interface{})
 	}
 	log.Printf("[INFO] Updating OpsGenie escalation '%s'", name)
 
-	_, err = client.Update(context.Background(), updateRequest)
+	_, err = client.Update(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceOpsgenieEscalationDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsgenieEscalationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Deleting OpsGenie escalation '%s'", d.Get("name").(string))
-	client, err := escalation.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).escalationClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	deleteRequest := &escalation.DeleteRequest{
 		IdentifierType: escalation.Id,
 		Identifier:     d.Id(),
 	}
 
-	_, err = client.Delete(context.Background(), deleteRequest)
+	_, err = client.Delete(ctx, deleteRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -349,3 +351,20 @@ func validateEscalationParticipantType(v interface{}, k string) (ws []string, er
 	}
 	return
 }
+
+func lookupOpsgenieEscalation(identifierType escalation.Identifier) importLookup {
+	return func(ctx context.Context, meta interface{}, identifier string) (string, error) {
+		client, err := meta.(*OpsgenieClient).escalationClient()
+		if err != nil {
+			return "", err
+		}
+		result, err := client.Get(ctx, &escalation.GetRequest{
+			IdentifierType: identifierType,
+			Identifier:     identifier,
+		})
+		if err != nil {
+			return "", err
+		}
+		return result.Id, nil
+	}
+}
@@ -3,8 +3,10 @@ package opsgenie
 import (
 	"context"
 	"fmt"
+	"log"
 	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/heartbeat"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
@@ -12,12 +14,18 @@ import (
 
 func resourceOpsgenieHeartbeat() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsgenieHeartbeatCreate,
-		Read:   handleNonExistentResource(resourceOpsgenieHeartbeatRead),
-		Update: resourceOpsgenieHeartbeatUpdate,
-		Delete: resourceOpsgenieHeartbeatDelete,
+		CreateContext: resourceOpsgenieHeartbeatCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsgenieHeartbeatRead),
+		UpdateContext: resourceOpsgenieHeartbeatUpdate,
+		DeleteContext: resourceOpsgenieHeartbeatDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				// the initial ping is only sent on create, imported heartbeats
+				// take the default
+				d.Set("initial_ping", false)
+				return importStateByIdOrName("heartbeat", lookupOpsgenieHeartbeat)(ctx, d, meta)
+			},
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -62,14 +70,19 @@ func resourceOpsgenieHeartbeat() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"initial_ping": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
 
-func resourceOpsgenieHeartbeatCreate(d *schema.ResourceData, meta interface{}) error {
-	client, err := heartbeat.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieHeartbeatCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).heartbeatClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -96,23 +109,32 @@ func resourceOpsgenieHeartbeatCreate(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
-	result, err := client.Add(context.Background(), addRequest)
+	result, err := client.Add(ctx, addRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(result.Heartbeat.Name)
 
-	return resourceOpsgenieHeartbeatRead(d, meta)
+	// a first ping keeps a new heartbeat from expiring before whatever pings
+	// it is deployed
+	if d.Get("initial_ping").(bool) {
+		log.Printf("[INFO] Sending the initial ping of OpsGenie Heartbeat '%s'", result.Heartbeat.Name)
+		if _, err := client.Ping(ctx, result.Heartbeat.Name); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diag.FromErr(resourceOpsgenieHeartbeatRead(ctx, d, meta))
 }
 
-func resourceOpsgenieHeartbeatRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := heartbeat.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieHeartbeatRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).heartbeatClient()
 	if err != nil {
 		return err
 	}
 
-	result, err := client.Get(context.Background(), d.Id())
+	result, err := client.Get(ctx, d.Id())
 	if err != nil {
 		return err
 	}
@@ -130,10 +152,10 @@ func resourceOpsgenieHeartbeatRead(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
-func resourceOpsgenieHeartbeatUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := heartbeat.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieHeartbeatUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).heartbeatClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -160,23 +182,23 @@ func resourceOpsgenieHeartbeatUpdate(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
-	_, err = client.Update(context.Background(), updateRequest)
+	_, err = client.Update(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceOpsgenieHeartbeatDelete(d *schema.ResourceData, meta interface{}) error {
-	client, err := heartbeat.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieHeartbeatDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).heartbeatClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	_, err = client.Delete(context.Background(), d.Id())
+	_, err = client.Delete(ctx, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -195,3 +217,17 @@ func validateOpsgenieHeartbeat(v interface{}, k string) (ws []string, errors []e
 
 	return
 }
+
+// lookupOpsgenieHeartbeat resolves a heartbeat name. Heartbeats are
+// identified by their name, so it is their id as well.
+func lookupOpsgenieHeartbeat(ctx context.Context, meta interface{}, identifier string) (string, error) {
+	client, err := meta.(*OpsgenieClient).heartbeatClient()
+	if err != nil {
+		return "", err
+	}
+	result, err := client.Get(ctx, identifier)
+	if err != nil {
+		return "", err
+	}
+	return result.Name, nil
+}
@@ -67,6 +67,11 @@ func TestAccOpsgenieHeartbeat_basic(t *testing.T) {
 					testCheckOpsGenieHeartbeatExists("opsgenie_heartbeat.test"),
 				),
 			},
+			{
+				ResourceName:      "opsgenie_heartbeat.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -2,6 +2,7 @@ package opsgenie
 
 import (
 	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/incident"
@@ -10,12 +11,13 @@ import (
 
 func resourceOpsgenieIncidentTemplate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsgenieIncidentTemplateCreate,
-		Read:   handleNonExistentResource(resourceOpsgenieIncidentTemplateRead),
-		Update: resourceOpsgenieIncidentTemplateUpdate,
-		Delete: resourceOpsgenieIncidentTemplateDelete,
+		CreateContext: resourceOpsgenieIncidentTemplateCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsgenieIncidentTemplateRead),
+		UpdateContext: resourceOpsgenieIncidentTemplateUpdate,
+		DeleteContext: resourceOpsgenieIncidentTemplateDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -81,10 +83,10 @@ func resourceOpsgenieIncidentTemplate() *schema.Resource {
 	}
 }
 
-func resourceOpsgenieIncidentTemplateCreate(d *schema.ResourceData, meta interface{}) error {
-	client, err := incident.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieIncidentTemplateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).incidentClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	createRequest := &incident.CreateIncidentTemplateRequest{
 		Name:                  d.Get("name").(string),
@@ -96,20 +98,20 @@ func resourceOpsgenieIncidentTemplateCreate(d *schema.ResourceData, meta interfa
 		ImpactedServices:      expandOpsgenieIncidentTemplateImpactedServices(d.Get("impacted_services").(*schema.Set)),
 		StakeholderProperties: expandOpsgenieIncidentTemplateStakeholderProperties(d.Get("stakeholder_properties").([]interface{})),
 	}
-	result, err := client.CreateIncidentTemplate(context.Background(), createRequest)
+	result, err := client.CreateIncidentTemplate(ctx, createRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(result.IncidentTemplateId)
-	return resourceOpsgenieIncidentTemplateRead(d, meta)
+	return diag.FromErr(resourceOpsgenieIncidentTemplateRead(ctx, d, meta))
 }
 
-func resourceOpsgenieIncidentTemplateRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := incident.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieIncidentTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).incidentClient()
 	if err != nil {
 		return err
 	}
-	result, err := client.GetIncidentTemplate(context.Background(), &incident.GetIncidentTemplateRequest{})
+	result, err := client.GetIncidentTemplate(ctx, &incident.GetIncidentTemplateRequest{})
 	if err != nil {
 		return err
 	}
@@ -136,10 +138,10 @@ func resourceOpsgenieIncidentTemplateRead(d *schema.ResourceData, meta interface
 	return nil
 }
 
-func resourceOpsgenieIncidentTemplateUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := incident.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieIncidentTemplateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).incidentClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	updateRequest := &incident.UpdateIncidentTemplateRequest{
 		IncidentTemplateId:    d.Id(),
@@ -152,22 +154,22 @@ func resourceOpsgenieIncidentTemplateUpdate(d *schema.ResourceData, meta interfa
 		ImpactedServices:      expandOpsgenieIncidentTemplateImpactedServices(d.Get("impacted_services").(*schema.Set)),
 		StakeholderProperties: expandOpsgenieIncidentTemplateStakeholderProperties(d.Get("stakeholder_properties").([]interface{})),
 	}
-	_, err = client.UpdateIncidentTemplate(context.Background(), updateRequest)
+	_, err = client.UpdateIncidentTemplate(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceOpsgenieIncidentTemplateDelete(d *schema.ResourceData, meta interface{}) error {
-	client, err := incident.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieIncidentTemplateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).incidentClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	deleteRequest := &incident.DeleteIncidentTemplateRequest{IncidentTemplateId: d.Id()}
-	_, err = client.DeleteIncidentTemplate(context.Background(), deleteRequest)
+	_, err = client.DeleteIncidentTemplate(ctx, deleteRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -0,0 +1,402 @@
+package opsgenie
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/integration"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
+)
+
+// integrationManagedFields are the integration fields which are configured
+// through dedicated attributes and therefore cannot be set in "settings".
+var integrationManagedFields = []string{
+	"id",
+	"name",
+	"type",
+	"enabled",
+	"allowWriteAccess",
+	"allowConfigurationAccess",
+	"ignoreRespondersFromPayload",
+	"suppressNotifications",
+	"responders",
+	"recipients",
+	"ownerTeam",
+	"assignedTeam",
+	"apiKey",
+}
+
+func resourceOpsgenieIntegration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOpsgenieIntegrationCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsgenieIntegrationRead),
+		UpdateContext: resourceOpsgenieIntegrationUpdate,
+		DeleteContext: resourceOpsgenieIntegrationDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 250),
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"allow_write_access": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"allow_configuration_access": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ignore_responders_from_payload": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"suppress_notifications": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"owner_team_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"responders": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateResponderType,
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"settings": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "{}",
+				ValidateFunc:     validateOpsgenieIntegrationSettings,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+				StateFunc: func(v interface{}) string {
+					normalized, _ := structure.NormalizeJsonString(v)
+					return normalized
+				},
+			},
+			"api_key": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceOpsgenieIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).integrationClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name := d.Get("name").(string)
+	integrationType := d.Get("type").(string)
+	allowWriteAccess := d.Get("allow_write_access").(bool)
+	allowConfigurationAccess := d.Get("allow_configuration_access").(bool)
+	ignoreRespondersFromPayload := d.Get("ignore_responders_from_payload").(bool)
+	suppressNotifications := d.Get("suppress_notifications").(bool)
+	ownerTeam := d.Get("owner_team_id").(string)
+
+	settings, err := structure.ExpandJsonFromString(d.Get("settings").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	createRequest := &integration.APIBasedIntegrationRequest{
+		Name:                        name,
+		Type:                        integrationType,
+		AllowWriteAccess:            &allowWriteAccess,
+		AllowConfigurationAccess:    &allowConfigurationAccess,
+		IgnoreRespondersFromPayload: &ignoreRespondersFromPayload,
+		SuppressNotifications:       &suppressNotifications,
+		Responders:                  expandOpsgenieIntegrationResponders(d),
+	}
+
+	if ownerTeam != "" {
+		createRequest.OwnerTeam = &og.OwnerTeam{
+			Id: ownerTeam,
+		}
+	}
+
+	log.Printf("[INFO] Creating OpsGenie %s integration '%s'", integrationType, name)
+
+	result, err := client.CreateApiBased(ctx, createRequest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(result.Id)
+	d.Set("api_key", result.ApiKey)
+
+	// Type specific fields cannot be sent on creation, so they are applied
+	// with a follow-up update which also takes care of the enabled state.
+	if len(settings) > 0 {
+		err = updateOpsgenieIntegration(ctx, d, client, settings, nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else if d.Get("enabled").(bool) {
+		_, err = client.Enable(ctx, &integration.EnableIntegrationRequest{
+			Id: result.Id,
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		log.Printf("[INFO] Enabled OpsGenie %s integration '%s'", integrationType, name)
+	}
+
+	return diag.FromErr(resourceOpsgenieIntegrationRead(ctx, d, meta))
+}
+
+func resourceOpsgenieIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).integrationClient()
+	if err != nil {
+		return err
+	}
+
+	result, err := client.Get(ctx, &integration.GetRequest{
+		Id: d.Id(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.Data["ownerTeam"] != nil {
+		ownerTeam := result.Data["ownerTeam"].(map[string]interface{})
+		d.Set("owner_team_id", ownerTeam["id"])
+	}
+	if result.Data["responders"] != nil {
+		d.Set("responders", flattenOpsgenieIntegrationRespondersWithoutOwner(d, result.Data["responders"].([]interface{})))
+	}
+	d.Set("name", result.Data["name"])
+	d.Set("type", result.Data["type"])
+	d.Set("enabled", result.Data["enabled"])
+	d.Set("allow_write_access", result.Data["allowWriteAccess"])
+	d.Set("allow_configuration_access", result.Data["allowConfigurationAccess"])
+	d.Set("ignore_responders_from_payload", result.Data["ignoreRespondersFromPayload"])
+	d.Set("suppress_notifications", result.Data["suppressNotifications"])
+	if apiKey, ok := result.Data["apiKey"].(string); ok && apiKey != "" {
+		d.Set("api_key", apiKey)
+	}
+
+	settings, err := flattenOpsgenieIntegrationSettings(d.Get("settings").(string), result.Data)
+	if err != nil {
+		return err
+	}
+	d.Set("settings", settings)
+
+	return nil
+}
+
+func resourceOpsgenieIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).integrationClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	o, n := d.GetChange("settings")
+	oldSettings, err := structure.ExpandJsonFromString(o.(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	settings, err := structure.ExpandJsonFromString(n.(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	removed := make([]string, 0)
+	for k := range oldSettings {
+		if _, ok := settings[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+
+	err = updateOpsgenieIntegration(ctx, d, client, settings, removed)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(resourceOpsgenieIntegrationRead(ctx, d, meta))
+}
+
+func resourceOpsgenieIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Deleting OpsGenie integration '%s'", d.Get("name").(string))
+	client, err := meta.(*OpsgenieClient).integrationClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.Delete(ctx, &integration.DeleteIntegrationRequest{
+		Id: d.Id(),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// updateOpsgenieIntegration replaces all fields of the integration, keeping the
+// fields which are neither managed by the resource nor part of settings as they
+// currently are in Opsgenie. The removed settings are left out, so Opsgenie
+// resets them to their defaults.
+func updateOpsgenieIntegration(ctx context.Context, d *schema.ResourceData, client *integration.Client, settings map[string]interface{}, removed []string) error {
+	// GET+PUT workaround since the Opsgenie Integration API does not support HTTP PATCH method
+	result, err := client.Get(ctx, &integration.GetRequest{
+		Id: d.Id(),
+	})
+	if err != nil {
+		log.Printf("Error occurred while performing GET for integration: %s", d.Id())
+		return err
+	}
+	otherFields := result.Data
+	if readOnlyFields, found := otherFields["_readOnly"]; found {
+		for _, key := range readOnlyFields.([]interface{}) {
+			delete(otherFields, key.(string))
+		}
+	}
+	delete(otherFields, "_readOnly")
+	for _, k := range removed {
+		delete(otherFields, k)
+	}
+	for k, v := range settings {
+		otherFields[k] = v
+	}
+	otherFields["allowWriteAccess"] = d.Get("allow_write_access").(bool)
+	otherFields["allowConfigurationAccess"] = d.Get("allow_configuration_access").(bool)
+
+	name := d.Get("name").(string)
+	enabled := d.Get("enabled").(bool)
+	ignoreRespondersFromPayload := d.Get("ignore_responders_from_payload").(bool)
+	suppressNotifications := d.Get("suppress_notifications").(bool)
+	ownerTeam := d.Get("owner_team_id").(string)
+
+	updateRequest := &integration.UpdateIntegrationRequest{
+		Id:                          d.Id(),
+		Name:                        name,
+		Type:                        d.Get("type").(string),
+		Enabled:                     &enabled,
+		IgnoreRespondersFromPayload: &ignoreRespondersFromPayload,
+		SuppressNotifications:       &suppressNotifications,
+		Responders:                  expandOpsgenieIntegrationResponders(d),
+		OtherFields:                 otherFields,
+	}
+
+	// ForceUpdateAllFields always sends these fields, so carry over their
+	// current values to avoid clearing them for integration types using them.
+	if v, ok := otherFields["url"].(string); ok {
+		updateRequest.WebhookUrl = v
+	}
+	if v, ok := otherFields["emailUsername"].(string); ok {
+		updateRequest.EmailUsername = v
+	}
+	if v, ok := otherFields["addAlertDescription"].(bool); ok {
+		updateRequest.AddAlertDescription = &v
+	}
+	if v, ok := otherFields["addAlertDetails"].(bool); ok {
+		updateRequest.AddAlertDetails = &v
+	}
+	if v, ok := otherFields["headers"].(map[string]interface{}); ok {
+		updateRequest.Headers = make(map[string]string, len(v))
+		for hk, hv := range v {
+			updateRequest.Headers[hk] = fmt.Sprintf("%v", hv)
+		}
+	}
+
+	if ownerTeam != "" {
+		updateRequest.OwnerTeam = &og.OwnerTeam{
+			Id: ownerTeam,
+		}
+	}
+
+	log.Printf("[INFO] Updating OpsGenie integration '%s'", name)
+
+	_, err = client.ForceUpdateAllFields(ctx, updateRequest)
+	return err
+}
+
+// flattenOpsgenieIntegrationSettings returns the JSON encoded values of the
+// configured settings keys. Opsgenie returns every field of the integration
+// type, so keys which are not configured are left out to avoid spurious diffs.
+func flattenOpsgenieIntegrationSettings(configured string, data map[string]interface{}) (string, error) {
+	configuredSettings, err := structure.ExpandJsonFromString(configured)
+	if err != nil {
+		return "", err
+	}
+	settings := make(map[string]interface{}, len(configuredSettings))
+	for k := range configuredSettings {
+		if v, ok := data[k]; ok {
+			settings[k] = v
+		}
+	}
+	if len(settings) == 0 {
+		return "{}", nil
+	}
+	return structure.FlattenJsonToString(settings)
+}
+
+// flattenOpsgenieIntegrationRespondersWithoutOwner leaves out the owner team,
+// which Opsgenie adds to the responders implicitly, unless it is configured.
+func flattenOpsgenieIntegrationRespondersWithoutOwner(d *schema.ResourceData, r []interface{}) []map[string]interface{} {
+	ownerTeam := d.Get("owner_team_id").(string)
+	for _, configured := range expandOpsgenieIntegrationResponders(d) {
+		if configured.Type == "team" && configured.Id == ownerTeam {
+			ownerTeam = ""
+		}
+	}
+	responders := make([]map[string]interface{}, 0, len(r))
+	for _, responder := range flattenIntegrationResponders(r) {
+		if ownerTeam != "" && responder["type"] == "team" && responder["id"] == ownerTeam {
+			continue
+		}
+		responders = append(responders, responder)
+	}
+	return responders
+}
+
+func validateOpsgenieIntegrationSettings(v interface{}, k string) (ws []string, errors []error) {
+	var settings map[string]interface{}
+	if err := json.Unmarshal([]byte(v.(string)), &settings); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a JSON object: %s", k, err))
+		return
+	}
+	for _, field := range integrationManagedFields {
+		if _, ok := settings[field]; ok {
+			errors = append(errors, fmt.Errorf("%q cannot contain %q, use the corresponding resource attribute instead", k, field))
+		}
+	}
+	return
+}
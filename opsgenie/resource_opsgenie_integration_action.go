@@ -10,522 +10,216 @@ import (
 	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/integration"
 )
 
 func resourceOpsgenieIntegrationAction() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsgenieIntegrationActionCreate,
-		Read:   handleNonExistentResource(resourceOpsgenieIntegrationActionRead),
-		Update: resourceOpsgenieIntegrationActionUpdate,
-		Delete: resourceOpsgenieIntegrationActionDelete,
+		CreateContext: resourceOpsgenieIntegrationActionCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsgenieIntegrationActionRead),
+		UpdateContext: resourceOpsgenieIntegrationActionUpdate,
+		DeleteContext: resourceOpsgenieIntegrationActionDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: validateConditionsDiff(
+			conditionsBlock{path: []string{"create", "filter"}, matchType: "type"},
+			conditionsBlock{path: []string{"close", "filter"}, matchType: "type"},
+			conditionsBlock{path: []string{"acknowledge", "filter"}, matchType: "type"},
+			conditionsBlock{path: []string{"add_note", "filter"}, matchType: "type"},
+			conditionsBlock{path: []string{"ignore", "filter"}, matchType: "type"},
+		),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				d.Set("authoritative", true)
+				return []*schema.ResourceData{d}, nil
+			},
 		},
 		Schema: map[string]*schema.Schema{
 			"integration_id": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"authoritative": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
 			"create": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"type": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "create",
-						},
-						"order": {
-							Type:     schema.TypeInt,
-							Optional: true,
-							Default:  1,
-						},
-						"priority": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"filter": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"type": {
-										Type:         schema.TypeString,
-										Required:     true,
-										ValidateFunc: validation.StringInSlice([]string{"match-all", "match-any-condition", "match-all-conditions"}, false),
-									},
-									"conditions": {
-										Type:     schema.TypeSet,
-										Optional: true,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"field": {
-													Type:     schema.TypeString,
-													Required: true,
-												},
-												"key": {
-													Type:     schema.TypeString,
-													Optional: true,
-												},
-												"not": {
-													Type:     schema.TypeBool,
-													Optional: true,
-													Default:  false,
-												},
-												"operation": {
-													Type:     schema.TypeString,
-													Required: true,
-												},
-												"expected_value": {
-													Type:     schema.TypeString,
-													Optional: true,
-												},
-												"order": {
-													Type:     schema.TypeInt,
-													Optional: true,
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-						"user": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "{{user}}",
-						},
-						"note": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "{{note}}",
-						},
-						"alias": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "{{alias}}",
-						},
-						"source": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "{{source}}",
-						},
-						"message": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "{{message}}",
-						},
-						"description": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "{{description}}",
-						},
-						"entity": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "{{entity}}",
-						},
-						"append_attachments": {
-							Type:     schema.TypeBool,
-							Default:  true,
-							Optional: true,
-						},
-						"ignore_alert_actions_from_payload": {
-							Type:     schema.TypeBool,
-							Default:  false,
-							Optional: true,
-						},
-						"ignore_responders_from_payload": {
-							Type:     schema.TypeBool,
-							Default:  false,
-							Optional: true,
-						},
-						"ignore_teams_from_payload": {
-							Type:     schema.TypeBool,
-							Default:  false,
-							Optional: true,
-						},
-						"ignore_tags_from_payload": {
-							Type:     schema.TypeBool,
-							Default:  false,
-							Optional: true,
-						},
-						"ignore_extra_properties_from_payload": {
-							Type:     schema.TypeBool,
-							Default:  false,
-							Optional: true,
-						},
-						"alert_actions": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
-						"responders": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"type": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-									"id": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-								},
-							},
-						},
-						"tags": {
-							Type:     schema.TypeSet,
-							Optional: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Set: schema.HashString,
-						},
-						"extra_properties": {
-							Type:     schema.TypeMap,
-							Optional: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
-						"custom_priority": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-					},
+					Schema: integrationActionSchema("create"),
 				},
 			},
 			"close": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"type": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "close",
-						},
-						"order": {
-							Type:     schema.TypeInt,
-							Optional: true,
-							Default:  1,
-						},
-						"filter": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"type": {
-										Type:         schema.TypeString,
-										Required:     true,
-										ValidateFunc: validation.StringInSlice([]string{"match-all", "match-any-condition", "match-all-conditions"}, false),
-									},
-									"conditions": {
-										Type:     schema.TypeSet,
-										Optional: true,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"field": {
-													Type:     schema.TypeString,
-													Required: true,
-												},
-												"key": {
-													Type:     schema.TypeString,
-													Optional: true,
-												},
-												"not": {
-													Type:     schema.TypeBool,
-													Optional: true,
-													Default:  false,
-												},
-												"operation": {
-													Type:     schema.TypeString,
-													Required: true,
-												},
-												"expected_value": {
-													Type:     schema.TypeString,
-													Optional: true,
-												},
-												"order": {
-													Type:     schema.TypeInt,
-													Optional: true,
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-						"user": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "{{user}}",
-						},
-						"note": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "{{note}}",
-						},
-						"alias": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "{{alias}}",
-						},
-					},
+					Schema: integrationActionSchema("close"),
 				},
 			},
 			"acknowledge": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"type": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "acknowledge",
-						},
-						"order": {
-							Type:     schema.TypeInt,
-							Optional: true,
-							Default:  1,
-						},
-						"filter": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"type": {
-										Type:         schema.TypeString,
-										Required:     true,
-										ValidateFunc: validation.StringInSlice([]string{"match-all", "match-any-condition", "match-all-conditions"}, false),
-									},
-									"conditions": {
-										Type:     schema.TypeSet,
-										Optional: true,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"field": {
-													Type:     schema.TypeString,
-													Required: true,
-												},
-												"key": {
-													Type:     schema.TypeString,
-													Optional: true,
-												},
-												"not": {
-													Type:     schema.TypeBool,
-													Optional: true,
-													Default:  false,
-												},
-												"operation": {
-													Type:     schema.TypeString,
-													Required: true,
-												},
-												"expected_value": {
-													Type:     schema.TypeString,
-													Optional: true,
-												},
-												"order": {
-													Type:     schema.TypeInt,
-													Optional: true,
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-						"user": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "{{user}}",
-						},
-						"note": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "{{note}}",
-						},
-						"alias": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "{{alias}}",
-						},
-					},
+					Schema: integrationActionSchema("acknowledge"),
 				},
 			},
 			"add_note": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"type": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "addNote",
-						},
-						"order": {
-							Type:     schema.TypeInt,
-							Optional: true,
-							Default:  1,
-						},
-						"filter": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"type": {
-										Type:         schema.TypeString,
-										Required:     true,
-										ValidateFunc: validation.StringInSlice([]string{"match-all", "match-any-condition", "match-all-conditions"}, false),
-									},
-									"conditions": {
-										Type:     schema.TypeSet,
-										Optional: true,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"field": {
-													Type:     schema.TypeString,
-													Required: true,
-												},
-												"key": {
-													Type:     schema.TypeString,
-													Optional: true,
-												},
-												"not": {
-													Type:     schema.TypeBool,
-													Optional: true,
-													Default:  false,
-												},
-												"operation": {
-													Type:     schema.TypeString,
-													Required: true,
-												},
-												"expected_value": {
-													Type:     schema.TypeString,
-													Optional: true,
-												},
-												"order": {
-													Type:     schema.TypeInt,
-													Optional: true,
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-						"user": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "{{user}}",
-						},
-						"note": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "{{note}}",
-						},
-						"alias": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "{{alias}}",
-						},
-					},
+					Schema: integrationActionSchema("addNote"),
 				},
 			},
 			"ignore": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"type": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "ignore",
-						},
-						"order": {
-							Type:     schema.TypeInt,
-							Optional: true,
-							Default:  1,
-						},
-						"filter": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"type": {
-										Type:         schema.TypeString,
-										Required:     true,
-										ValidateFunc: validation.StringInSlice([]string{"match-all", "match-any-condition", "match-all-conditions"}, false),
-									},
-									"conditions": {
-										Type:     schema.TypeSet,
-										Optional: true,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"field": {
-													Type:     schema.TypeString,
-													Required: true,
-												},
-												"key": {
-													Type:     schema.TypeString,
-													Optional: true,
-												},
-												"not": {
-													Type:     schema.TypeBool,
-													Optional: true,
-													Default:  false,
-												},
-												"operation": {
-													Type:     schema.TypeString,
-													Required: true,
-												},
-												"expected_value": {
-													Type:     schema.TypeString,
-													Optional: true,
-												},
-												"order": {
-													Type:     schema.TypeInt,
-													Optional: true,
-												},
-											},
-										},
-									},
-								},
-							},
-						},
+					Schema: integrationActionSchema("ignore"),
+				},
+			},
+		},
+	}
+}
+
+// integrationActionSchema returns the schema of an action of the given type.
+// Only create actions have the fields of the alert, and ignore actions have
+// no user, note and alias.
+func integrationActionSchema(actionType string) map[string]*schema.Schema {
+	actionSchema := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"type": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  actionType,
+		},
+		"order": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  1,
+		},
+		"filter": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(conditionMatchTypes, false),
 					},
+					"conditions": conditionsSchema(schema.TypeSet, nil),
 				},
 			},
 		},
 	}
+	if actionType == string(integration.Ignore) {
+		return actionSchema
+	}
+
+	actionSchema["user"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "{{user}}",
+	}
+	actionSchema["note"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "{{note}}",
+	}
+	actionSchema["alias"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "{{alias}}",
+	}
+	if actionType != string(integration.Create) {
+		return actionSchema
+	}
+
+	actionSchema["priority"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	actionSchema["custom_priority"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	actionSchema["source"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "{{source}}",
+	}
+	actionSchema["message"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "{{message}}",
+	}
+	actionSchema["description"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "{{description}}",
+	}
+	actionSchema["entity"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "{{entity}}",
+	}
+	actionSchema["append_attachments"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Default:  true,
+		Optional: true,
+	}
+	for _, ignoreFromPayload := range []string{"ignore_alert_actions_from_payload", "ignore_responders_from_payload", "ignore_teams_from_payload", "ignore_tags_from_payload", "ignore_extra_properties_from_payload"} {
+		actionSchema[ignoreFromPayload] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Default:  false,
+			Optional: true,
+		}
+	}
+	actionSchema["alert_actions"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	actionSchema["responders"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"id": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+			},
+		},
+	}
+	actionSchema["tags"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Set: schema.HashString,
+	}
+	actionSchema["extra_properties"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	return actionSchema
 }
 
 func convertInterfaceSliceToString(input []interface{}) []string {
@@ -574,33 +268,16 @@ func expandOpsgenieFilter(input []interface{}) integration.Filter {
 	return filter
 }
 
+// expandOpsgenieIntegrationConditions expands conditions the way integration
+// actions expect extra properties, as a field holding the key.
 func expandOpsgenieIntegrationConditions(input *schema.Set) []og.Condition {
-	conditions := make([]og.Condition, 0, input.Len())
-
-	if input == nil {
-		return conditions
-	}
-	for _, v := range input.List() {
-		inputMap := v.(map[string]interface{})
-		condition := og.Condition{}
-		key := inputMap["key"].(string)
-		if key != "" {
-			condition.Key = inputMap["key"].(string)
-		}
-		condition.Field = og.ConditionFieldType(inputMap["field"].(string))
+	conditions := expandOpsgenieConditions(input.List())
+	for i, condition := range conditions {
 		if condition.Field == og.ExtraProperties {
-			condition.Field = og.ConditionFieldType(fmt.Sprintf("extra_properties_key_prefix-%s", condition.Key))
-			condition.Key = ""
+			conditions[i].Field = og.ConditionFieldType(fmt.Sprintf("extra_properties_key_prefix-%s", condition.Key))
+			conditions[i].Key = ""
 		}
-		isNot := inputMap["not"].(bool)
-		condition.IsNot = &isNot
-		condition.Operation = og.ConditionOperation(inputMap["operation"].(string))
-		condition.ExpectedValue = inputMap["expected_value"].(string)
-		order := inputMap["order"].(int)
-		condition.Order = &order
-		conditions = append(conditions, condition)
 	}
-
 	return conditions
 }
 
@@ -677,24 +354,12 @@ func flattenOpsgenieFilter(input *integration.Filter) []map[string]interface{} {
 	rules := make([]map[string]interface{}, 0, 1)
 	out := make(map[string]interface{})
 	out["type"] = input.ConditionMatchType
-	conditions := make([]map[string]interface{}, 0, len(input.Conditions))
-	for _, r := range input.Conditions {
-		conditionMap := make(map[string]interface{})
-		conditionMap["order"] = r.Order
-		key, found := strings.CutPrefix(string(r.Field), "extra_properties_key_prefix-")
-		if found {
-			conditionMap["key"] = key
-			conditionMap["field"] = og.ExtraProperties
-		} else {
-			conditionMap["field"] = r.Field
-			if r.Key != "" {
-				conditionMap["key"] = r.Key
-			}
+	conditions := flattenOpsgenieConditions(input.Conditions, true)
+	for _, condition := range conditions {
+		if key, found := strings.CutPrefix(string(condition["field"].(og.ConditionFieldType)), "extra_properties_key_prefix-"); found {
+			condition["field"] = og.ExtraProperties
+			condition["key"] = key
 		}
-		conditionMap["expected_value"] = r.ExpectedValue
-		conditionMap["operation"] = r.Operation
-		conditionMap["not"] = r.IsNot
-		conditions = append(conditions, conditionMap)
 	}
 	out["conditions"] = conditions
 	rules = append(rules, out)
@@ -756,41 +421,140 @@ func flattenOpsgenieIntegrationActions(input []integration.IntegrationAction) []
 	return actions
 }
 
-func resourceOpsgenieIntegrationActionCreate(d *schema.ResourceData, meta interface{}) error {
-	client, err := integration.NewClient(meta.(*OpsgenieClient).client.Config)
+// integrationActionTypes are the attributes holding the actions of each type.
+var integrationActionTypes = []string{"create", "close", "acknowledge", "add_note", "ignore"}
+
+func integrationActionsByType(result *integration.ActionsResult) map[string][]integration.IntegrationAction {
+	return map[string][]integration.IntegrationAction{
+		"create":      result.Create,
+		"close":       result.Close,
+		"acknowledge": result.Acknowledge,
+		"add_note":    result.AddNote,
+		"ignore":      result.Ignore,
+	}
+}
+
+func newUpdateAllIntegrationActionsRequest(integrationId string, actions map[string][]integration.IntegrationAction) *integration.UpdateAllIntegrationActionsRequest {
+	return &integration.UpdateAllIntegrationActionsRequest{
+		Id:          integrationId,
+		Create:      actions["create"],
+		Close:       actions["close"],
+		Acknowledge: actions["acknowledge"],
+		AddNote:     actions["add_note"],
+		Ignore:      actions["ignore"],
+	}
+}
+
+func integrationActionNames(input interface{}) map[string]bool {
+	names := make(map[string]bool)
+	if input == nil {
+		return names
+	}
+	for _, v := range input.([]interface{}) {
+		names[v.(map[string]interface{})["name"].(string)] = true
+	}
+	return names
+}
+
+// mergeOpsgenieIntegrationActions replaces the existing actions with the
+// configured actions of the same name and removes the managed actions which
+// are no longer configured. Actions which aren't managed are kept.
+func mergeOpsgenieIntegrationActions(existing, configured []integration.IntegrationAction, managed map[string]bool) []integration.IntegrationAction {
+	byName := make(map[string]integration.IntegrationAction, len(configured))
+	for _, action := range configured {
+		byName[action.Name] = action
+	}
+
+	merged := make([]integration.IntegrationAction, 0, len(existing)+len(configured))
+	for _, action := range existing {
+		if replacement, ok := byName[action.Name]; ok {
+			merged = append(merged, replacement)
+			delete(byName, action.Name)
+		} else if !managed[action.Name] {
+			merged = append(merged, action)
+		}
+	}
+	for _, action := range configured {
+		if _, ok := byName[action.Name]; ok {
+			merged = append(merged, action)
+		}
+	}
+	return merged
+}
+
+// filterOpsgenieIntegrationActions returns the actions named in the given
+// list, in the order of the list.
+func filterOpsgenieIntegrationActions(actions []integration.IntegrationAction, input interface{}) []integration.IntegrationAction {
+	byName := make(map[string]integration.IntegrationAction, len(actions))
+	for _, action := range actions {
+		byName[action.Name] = action
+	}
+
+	filtered := make([]integration.IntegrationAction, 0)
+	if input == nil {
+		return filtered
+	}
+	for _, v := range input.([]interface{}) {
+		if action, ok := byName[v.(map[string]interface{})["name"].(string)]; ok {
+			filtered = append(filtered, action)
+		}
+	}
+	return filtered
+}
+
+func resourceOpsgenieIntegrationActionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).integrationClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	integrationId := d.Get("integration_id").(string)
-	updateRequest := &integration.UpdateAllIntegrationActionsRequest{
-		Id:          integrationId,
-		Create:      expandOpsgenieIntegrationActions(d.Get("create")),
-		Close:       expandOpsgenieIntegrationActions(d.Get("close")),
-		Acknowledge: expandOpsgenieIntegrationActions(d.Get("acknowledge")),
-		AddNote:     expandOpsgenieIntegrationActions(d.Get("add_note")),
-		Ignore:      expandOpsgenieIntegrationActions(d.Get("ignore")),
+	defer meta.(*OpsgenieClient).lock("integration-actions/" + integrationId)()
+
+	actions := make(map[string][]integration.IntegrationAction, len(integrationActionTypes))
+	for _, actionType := range integrationActionTypes {
+		actions[actionType] = expandOpsgenieIntegrationActions(d.Get(actionType))
 	}
 
+	// a non-authoritative resource only changes the actions it manages
+	if !d.Get("authoritative").(bool) {
+		existing, err := client.GetActions(ctx, &integration.GetIntegrationActionsRequest{
+			Id: integrationId,
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for actionType, existingActions := range integrationActionsByType(existing) {
+			oldActions, newActions := d.GetChange(actionType)
+			managed := integrationActionNames(oldActions)
+			for name := range integrationActionNames(newActions) {
+				managed[name] = true
+			}
+			actions[actionType] = mergeOpsgenieIntegrationActions(existingActions, actions[actionType], managed)
+		}
+	}
+
+	updateRequest := newUpdateAllIntegrationActionsRequest(integrationId, actions)
+
 	log.Printf("[INFO] Creating OpsGenie integration actions for '%s'", integrationId)
-	result, err := client.UpdateAllActions(context.Background(), updateRequest)
+	result, err := client.UpdateAllActions(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(result.Parent.Id)
 	d.Set("integration_id", result.Parent.Id)
 
-	return resourceOpsgenieIntegrationActionRead(d, meta)
+	return diag.FromErr(resourceOpsgenieIntegrationActionRead(ctx, d, meta))
 }
 
-func resourceOpsgenieIntegrationActionRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := integration.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieIntegrationActionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).integrationClient()
 	if err != nil {
 		return err
 	}
 
-	result, err := client.GetActions(context.Background(), &integration.GetIntegrationActionsRequest{
+	result, err := client.GetActions(ctx, &integration.GetIntegrationActionsRequest{
 		BaseRequest: ogClient.BaseRequest{},
 		Id:          d.Id(),
 	})
@@ -799,26 +563,31 @@ func resourceOpsgenieIntegrationActionRead(d *schema.ResourceData, meta interfac
 	}
 	d.SetId(result.Parent.Id)
 	d.Set("integration_id", result.Parent.Id)
-	d.Set("create", flattenOpsgenieIntegrationActions(result.Create))
-	d.Set("close", flattenOpsgenieIntegrationActions(result.Close))
-	d.Set("acknowledge", flattenOpsgenieIntegrationActions(result.Acknowledge))
-	d.Set("add_note", flattenOpsgenieIntegrationActions(result.AddNote))
-	d.Set("ignore", flattenOpsgenieIntegrationActions(result.Ignore))
+	authoritative := d.Get("authoritative").(bool)
+	for actionType, actions := range integrationActionsByType(result) {
+		// a non-authoritative resource ignores the actions it doesn't manage
+		if !authoritative {
+			actions = filterOpsgenieIntegrationActions(actions, d.Get(actionType))
+		}
+		d.Set(actionType, flattenOpsgenieIntegrationActions(actions))
+	}
 
 	return nil
 }
 
-func resourceOpsgenieIntegrationActionUpdate(d *schema.ResourceData, meta interface{}) error {
-	return resourceOpsgenieIntegrationActionCreate(d, meta)
+func resourceOpsgenieIntegrationActionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceOpsgenieIntegrationActionCreate(ctx, d, meta)
 }
 
-func resourceOpsgenieIntegrationActionDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsgenieIntegrationActionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Deleting OpsGenie api integration actions for '%s'", d.Get("integration_id").(string))
-	client, err := integration.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).integrationClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
+	defer meta.(*OpsgenieClient).lock("integration-actions/" + d.Get("integration_id").(string))()
+
 	deleteRequest := &integration.UpdateAllIntegrationActionsRequest{
 		Id:          d.Get("integration_id").(string),
 		Create:      []integration.IntegrationAction{},
@@ -828,11 +597,29 @@ func resourceOpsgenieIntegrationActionDelete(d *schema.ResourceData, meta interf
 		Ignore:      []integration.IntegrationAction{},
 	}
 
-	_, err = client.UpdateAllActions(context.Background(), deleteRequest)
+	// a non-authoritative resource only removes the actions it manages
+	if !d.Get("authoritative").(bool) {
+		existing, err := client.GetActions(ctx, &integration.GetIntegrationActionsRequest{
+			Id: deleteRequest.Id,
+		})
+		if err != nil {
+			if apiError, ok := err.(*ogClient.ApiError); ok && apiError.StatusCode == 404 {
+				return nil
+			}
+			return diag.FromErr(err)
+		}
+		actions := integrationActionsByType(existing)
+		for actionType := range actions {
+			actions[actionType] = mergeOpsgenieIntegrationActions(actions[actionType], nil, integrationActionNames(d.Get(actionType)))
+		}
+		deleteRequest = newUpdateAllIntegrationActionsRequest(deleteRequest.Id, actions)
+	}
+
+	_, err = client.UpdateAllActions(ctx, deleteRequest)
 	if err != nil {
 		apiError := err.(*ogClient.ApiError)
 		if apiError.StatusCode != 404 {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
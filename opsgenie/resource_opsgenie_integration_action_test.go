@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"os"
 	"strings"
 	"testing"
 
@@ -14,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/integration"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
 )
 
 func init() {
@@ -78,6 +78,63 @@ func TestAccOpsGenieIntegrationAction_basic(t *testing.T) {
 	})
 }
 
+func TestAccOpsGenieIntegrationAction_nonAuthoritative(t *testing.T) {
+	rs := acctest.RandString(6)
+	var integrationId string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckOpsGenieIntegrationActionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpsGenieIntegrationAction_nonAuthoritative(rs, "P5"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieIntegrationActionExists("opsgenie_integration_action.test"),
+					resource.TestCheckResourceAttr("opsgenie_integration_action.test", "close.#", "1"),
+					func(s *terraform.State) error {
+						integrationId = s.RootModule().Resources["opsgenie_integration_action.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// an action added outside of Terraform is kept on updates
+				PreConfig: func() {
+					client, _ := integration.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+					actions, err := client.GetActions(context.Background(), &integration.GetIntegrationActionsRequest{Id: integrationId})
+					if err != nil {
+						t.Fatal(err)
+					}
+					_, err = client.UpdateAllActions(context.Background(), &integration.UpdateAllIntegrationActionsRequest{
+						Id:          integrationId,
+						Create:      actions.Create,
+						Close:       append(actions.Close, integration.IntegrationAction{Type: integration.Close, Name: "Unmanaged close action", Alias: "{{alias}}", Order: 2, Filter: &integration.Filter{ConditionMatchType: og.MatchAll}}),
+						Acknowledge: actions.Acknowledge,
+						AddNote:     actions.AddNote,
+						Ignore:      actions.Ignore,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccOpsGenieIntegrationAction_nonAuthoritative(rs, "P4"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("opsgenie_integration_action.test", "close.#", "1"),
+					resource.TestCheckResourceAttr("opsgenie_integration_action.test", "close.0.filter.0.conditions.0.expected_value", "P4"),
+					testCheckOpsGenieIntegrationActionNames("opsgenie_integration_action.test", "Test close action", "Unmanaged close action"),
+				),
+			},
+			{
+				// only the managed action is removed when the resource is destroyed
+				Config: testAccOpsGenieIntegrationAction_nonAuthoritativeRemoved(rs),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieIntegrationActionNames("opsgenie_api_integration.test", "Unmanaged close action"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccOpsGenieIntegrationAction_custompriority(t *testing.T) {
 	customPriority := "{{condition_name.extract(/^\\[(\\S+)\\].*$/, 1)}"
 	customPriorityEscaped := "{{condition_name.extract(/^\\\\[(\\\\S+)\\\\].*$/, 1)}"
@@ -220,6 +277,37 @@ func testCheckOpsGenieIntegrationActionExists(name string) resource.TestCheckFun
 	}
 }
 
+func testCheckOpsGenieIntegrationActionNames(name string, closeNames ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		client, err := integration.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+		if err != nil {
+			return err
+		}
+		Id := rs.Primary.Attributes["id"]
+
+		iAction, err := client.GetActions(context.Background(), &integration.GetIntegrationActionsRequest{
+			Id: Id,
+		})
+		if err != nil {
+			return fmt.Errorf("Bad: ApiIntegration with id %q does not exist", Id)
+		}
+		if len(iAction.Close) != len(closeNames) {
+			return fmt.Errorf("Bad: ApiIntegration with id %q has %d close actions, expected %d", Id, len(iAction.Close), len(closeNames))
+		}
+		for i, action := range iAction.Close {
+			if action.Name != closeNames[i] {
+				return fmt.Errorf("Bad: Close action %d of ApiIntegration with id %q is %q, expected %q", i, Id, action.Name, closeNames[i])
+			}
+		}
+		return nil
+	}
+}
+
 func testCheckOpsGenieIntegrationActionCustomPriorityExists(name, customPriority string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
@@ -272,6 +360,39 @@ resource "opsgenie_api_integration" "test" {
 `, rString)
 }
 
+func testAccOpsGenieIntegrationAction_nonAuthoritative(rString, priority string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_integration_action" "test" {
+  integration_id = opsgenie_api_integration.test.id
+  authoritative  = false
+  close {
+    name = "Test close action"
+    filter {
+      type = "match-all-conditions"
+      conditions {
+        field = "priority"
+        operation = "equals"
+        expected_value = "%s"
+      }
+    }
+  }
+}
+resource "opsgenie_api_integration" "test" {
+  type = "API"
+  name = "genieintegration-%s"
+}
+`, priority, rString)
+}
+
+func testAccOpsGenieIntegrationAction_nonAuthoritativeRemoved(rString string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_api_integration" "test" {
+  type = "API"
+  name = "genieintegration-%s"
+}
+`, rString)
+}
+
 func testAccOpsGenieIntegrationAction_custompriority(rString, crString string) string {
 	return fmt.Sprintf(`
 resource "opsgenie_api_integration" "test" {
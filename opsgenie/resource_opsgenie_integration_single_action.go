@@ -0,0 +1,238 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/integration"
+)
+
+// resourceOpsgenieIntegrationSingleAction manages one action of an
+// integration, keyed by its type and name. The other actions of the
+// integration are left untouched.
+func resourceOpsgenieIntegrationSingleAction() *schema.Resource {
+	actionSchema := integrationActionSchema(string(integration.Create))
+	actionSchema["integration_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+	actionSchema["type"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice([]string{"create", "close", "acknowledge", "addNote", "ignore"}, false),
+	}
+	actionSchema["name"].ForceNew = true
+
+	return &schema.Resource{
+		CreateContext: resourceOpsgenieIntegrationSingleActionCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsgenieIntegrationSingleActionRead),
+		UpdateContext: resourceOpsgenieIntegrationSingleActionUpdate,
+		DeleteContext: resourceOpsgenieIntegrationSingleActionDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourceOpsgenieIntegrationSingleActionCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idParts := strings.SplitN(d.Id(), "/", 3)
+				if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
+					return nil, fmt.Errorf("Unexpected format of ID (%q), expected integration_id/type/name", d.Id())
+				}
+				d.Set("integration_id", idParts[0])
+				d.Set("type", idParts[1])
+				d.Set("name", idParts[2])
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: actionSchema,
+	}
+}
+
+// resourceOpsgenieIntegrationSingleActionCustomizeDiff validates the filter
+// conditions and the attributes set for the type of the action.
+func resourceOpsgenieIntegrationSingleActionCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	err := validateConditionsDiff(conditionsBlock{path: []string{"filter"}, matchType: "type"})(ctx, d, meta)
+	if err != nil {
+		return err
+	}
+	return validateIntegrationSingleActionConfig(d.GetRawConfig())
+}
+
+// validateIntegrationSingleActionConfig rejects the attributes which don't
+// apply to the type of the action. The schema is the one of create actions,
+// and Opsgenie would drop the other attributes silently.
+func validateIntegrationSingleActionConfig(config cty.Value) error {
+	if config.IsNull() || !config.IsKnown() || !config.Type().HasAttribute("type") {
+		return nil
+	}
+	actionType := config.GetAttr("type")
+	if actionType.IsNull() || !actionType.IsKnown() {
+		return nil
+	}
+
+	supported := integrationActionSchema(actionType.AsString())
+	unsupported := make([]string, 0)
+	for k := range integrationActionSchema(string(integration.Create)) {
+		if _, ok := supported[k]; ok || !config.Type().HasAttribute(k) {
+			continue
+		}
+		v := config.GetAttr(k)
+		if v.IsNull() {
+			continue
+		}
+		// blocks which are not configured are empty rather than null
+		if v.IsKnown() && (v.Type().IsListType() || v.Type().IsSetType()) && v.LengthInt() == 0 {
+			continue
+		}
+		unsupported = append(unsupported, k)
+	}
+	if len(unsupported) == 0 {
+		return nil
+	}
+
+	sort.Strings(unsupported)
+	return fmt.Errorf("%s cannot be set for %s actions", strings.Join(unsupported, ", "), actionType.AsString())
+}
+
+// integrationActionsKey returns the key of the actions of the given type in
+// integrationActionsByType.
+func integrationActionsKey(actionType string) string {
+	if actionType == "addNote" {
+		return "add_note"
+	}
+	return actionType
+}
+
+func expandOpsgenieIntegrationSingleAction(d *schema.ResourceData) integration.IntegrationAction {
+	input := make(map[string]interface{})
+	for k := range integrationActionSchema(string(integration.Create)) {
+		input[k] = d.Get(k)
+	}
+	return expandOpsgenieIntegrationActions([]interface{}{input})[0]
+}
+
+// updateOpsgenieIntegrationSingleAction replaces the action of the resource on
+// its integration with the given actions. Creating an action which already
+// exists fails, so it isn't taken over silently.
+func updateOpsgenieIntegrationSingleAction(ctx context.Context, d *schema.ResourceData, meta interface{}, replacement []integration.IntegrationAction, create bool) error {
+	client, err := meta.(*OpsgenieClient).integrationClient()
+	if err != nil {
+		return err
+	}
+
+	integrationId := d.Get("integration_id").(string)
+	actionType := d.Get("type").(string)
+	name := d.Get("name").(string)
+	defer meta.(*OpsgenieClient).lock("integration-actions/" + integrationId)()
+
+	existing, err := client.GetActions(ctx, &integration.GetIntegrationActionsRequest{
+		Id: integrationId,
+	})
+	if err != nil {
+		return err
+	}
+
+	actions := integrationActionsByType(existing)
+	key := integrationActionsKey(actionType)
+	exists := false
+	for _, action := range actions[key] {
+		if action.Name == name {
+			exists = true
+		}
+	}
+	if exists && create {
+		return fmt.Errorf("%s action %q of integration %s already exists, import it to manage it", actionType, name, integrationId)
+	}
+	if !exists && len(replacement) == 0 {
+		return nil
+	}
+
+	actions[key] = mergeOpsgenieIntegrationActions(actions[key], replacement, map[string]bool{name: true})
+	_, err = client.UpdateAllActions(ctx, newUpdateAllIntegrationActionsRequest(integrationId, actions))
+	return err
+}
+
+func resourceOpsgenieIntegrationSingleActionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	action := expandOpsgenieIntegrationSingleAction(d)
+
+	log.Printf("[INFO] Creating OpsGenie %s action '%s' for integration '%s'", action.Type, action.Name, d.Get("integration_id").(string))
+	err := updateOpsgenieIntegrationSingleAction(ctx, d, meta, []integration.IntegrationAction{action}, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", d.Get("integration_id").(string), action.Type, action.Name))
+
+	return diag.FromErr(resourceOpsgenieIntegrationSingleActionRead(ctx, d, meta))
+}
+
+func resourceOpsgenieIntegrationSingleActionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).integrationClient()
+	if err != nil {
+		return err
+	}
+
+	integrationId := d.Get("integration_id").(string)
+	actionType := d.Get("type").(string)
+	name := d.Get("name").(string)
+
+	log.Printf("[INFO] Reading OpsGenie %s action '%s' of integration '%s'", actionType, name, integrationId)
+	result, err := client.GetActions(ctx, &integration.GetIntegrationActionsRequest{
+		Id: integrationId,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, action := range integrationActionsByType(result)[integrationActionsKey(actionType)] {
+		if action.Name != name {
+			continue
+		}
+		for k, v := range flattenOpsgenieIntegrationActions([]integration.IntegrationAction{action})[0] {
+			if k == "type" {
+				continue
+			}
+			d.Set(k, v)
+		}
+		d.Set("integration_id", integrationId)
+		d.Set("type", actionType)
+		return nil
+	}
+
+	log.Printf("[WARN] Removing %s action '%s' of integration '%s' because it's gone", actionType, name, integrationId)
+	d.SetId("")
+	return nil
+}
+
+func resourceOpsgenieIntegrationSingleActionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	action := expandOpsgenieIntegrationSingleAction(d)
+
+	log.Printf("[INFO] Updating OpsGenie %s action '%s' of integration '%s'", action.Type, action.Name, d.Get("integration_id").(string))
+	err := updateOpsgenieIntegrationSingleAction(ctx, d, meta, []integration.IntegrationAction{action}, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(resourceOpsgenieIntegrationSingleActionRead(ctx, d, meta))
+}
+
+func resourceOpsgenieIntegrationSingleActionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Deleting OpsGenie %s action '%s' of integration '%s'", d.Get("type").(string), d.Get("name").(string), d.Get("integration_id").(string))
+	err := updateOpsgenieIntegrationSingleAction(ctx, d, meta, nil, false)
+	if err != nil {
+		if apiErr, ok := err.(*ogClient.ApiError); ok && apiErr.StatusCode == 404 {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
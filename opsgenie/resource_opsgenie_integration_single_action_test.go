@@ -0,0 +1,202 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/integration"
+)
+
+func TestValidateIntegrationSingleActionConfig(t *testing.T) {
+	// config returns the configuration of a single action of the given type
+	// with the given attributes, the others are left out.
+	config := func(actionType string, attributes map[string]cty.Value) cty.Value {
+		block := resourceOpsgenieIntegrationSingleAction().CoreConfigSchema()
+		values := make(map[string]cty.Value)
+		for k, v := range block.ImpliedType().AttributeTypes() {
+			values[k] = cty.NullVal(v)
+			if _, ok := block.BlockTypes[k]; ok && v.IsListType() {
+				values[k] = cty.ListValEmpty(v.ElementType())
+			}
+		}
+		values["type"] = cty.StringVal(actionType)
+		for k, v := range attributes {
+			values[k] = v
+		}
+		return cty.ObjectVal(values)
+	}
+	responders := cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+		"type": cty.StringVal("team"),
+		"id":   cty.StringVal("team-id"),
+	})})
+
+	cases := []struct {
+		name   string
+		config cty.Value
+		err    string
+	}{
+		{name: "create", config: config("create", map[string]cty.Value{"message": cty.StringVal("test"), "responders": responders})},
+		{name: "close", config: config("close", map[string]cty.Value{"note": cty.StringVal("test")})},
+		{name: "close with message", config: config("close", map[string]cty.Value{"message": cty.StringVal("test")}), err: "message cannot be set for close actions"},
+		{name: "acknowledge with responders", config: config("acknowledge", map[string]cty.Value{"responders": responders}), err: "responders cannot be set for acknowledge actions"},
+		{name: "ignore", config: config("ignore", map[string]cty.Value{"order": cty.NumberIntVal(2)})},
+		{
+			name:   "ignore with note and tags",
+			config: config("ignore", map[string]cty.Value{"note": cty.StringVal("test"), "tags": cty.SetVal([]cty.Value{cty.StringVal("test")})}),
+			err:    "note, tags cannot be set for ignore actions",
+		},
+		{name: "unknown type", config: config("close", map[string]cty.Value{"type": cty.UnknownVal(cty.String), "message": cty.StringVal("test")})},
+	}
+	for _, c := range cases {
+		err := validateIntegrationSingleActionConfig(c.config)
+		if c.err == "" {
+			if err != nil {
+				t.Errorf("%s: expected no error, got %s", c.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s: expected an error containing %q, got %v", c.name, c.err, err)
+		}
+	}
+}
+
+func TestAccOpsGenieIntegrationSingleAction_basic(t *testing.T) {
+	rs := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckOpsGenieIntegrationSingleActionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpsGenieIntegrationSingleAction_basic(rs, "P5"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieIntegrationSingleActionExists("opsgenie_integration_single_action.create"),
+					testCheckOpsGenieIntegrationSingleActionExists("opsgenie_integration_single_action.close"),
+					resource.TestCheckResourceAttr("opsgenie_integration_single_action.create", "priority", "P2"),
+					resource.TestCheckResourceAttr("opsgenie_integration_single_action.create", "tags.#", "2"),
+					resource.TestCheckResourceAttr("opsgenie_integration_single_action.close", "filter.0.conditions.0.expected_value", "P5"),
+				),
+			},
+			{
+				Config: testAccOpsGenieIntegrationSingleAction_basic(rs, "P4"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieIntegrationSingleActionExists("opsgenie_integration_single_action.create"),
+					testCheckOpsGenieIntegrationSingleActionExists("opsgenie_integration_single_action.close"),
+					resource.TestCheckResourceAttr("opsgenie_integration_single_action.close", "filter.0.conditions.0.expected_value", "P4"),
+				),
+			},
+			{
+				ResourceName:            "opsgenie_integration_single_action.create",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ignore_teams_from_payload"},
+			},
+			{
+				ResourceName:            "opsgenie_integration_single_action.close",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"source", "message", "description", "entity", "append_attachments", "ignore_alert_actions_from_payload", "ignore_responders_from_payload", "ignore_teams_from_payload", "ignore_tags_from_payload", "ignore_extra_properties_from_payload"},
+			},
+		},
+	})
+}
+
+func testCheckOpsGenieIntegrationSingleActionDestroy(s *terraform.State) error {
+	client, err := integration.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "opsgenie_integration_single_action" {
+			continue
+		}
+		result, err := client.GetActions(context.Background(), &integration.GetIntegrationActionsRequest{
+			Id: rs.Primary.Attributes["integration_id"],
+		})
+		if err != nil {
+			if apiErr, ok := err.(*ogClient.ApiError); ok && apiErr.StatusCode == 404 {
+				continue
+			}
+			return err
+		}
+		for _, action := range integrationActionsByType(result)[integrationActionsKey(rs.Primary.Attributes["type"])] {
+			if action.Name == rs.Primary.Attributes["name"] {
+				return fmt.Errorf("Integration action %s still exists", rs.Primary.ID)
+			}
+		}
+	}
+	return nil
+}
+
+func testCheckOpsGenieIntegrationSingleActionExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		integrationId := rs.Primary.Attributes["integration_id"]
+
+		client, err := integration.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+		if err != nil {
+			return err
+		}
+		result, err := client.GetActions(context.Background(), &integration.GetIntegrationActionsRequest{
+			Id: integrationId,
+		})
+		if err != nil {
+			return fmt.Errorf("Bad: ApiIntegration with id %q does not exist", integrationId)
+		}
+		for _, action := range integrationActionsByType(result)[integrationActionsKey(rs.Primary.Attributes["type"])] {
+			if action.Name == rs.Primary.Attributes["name"] {
+				return nil
+			}
+		}
+		return fmt.Errorf("Bad: Integration action %q does not exist", rs.Primary.ID)
+	}
+}
+
+func testAccOpsGenieIntegrationSingleAction_basic(rString, priority string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_api_integration" "test" {
+  type = "API"
+  name = "genieintegration-%s"
+}
+resource "opsgenie_integration_single_action" "create" {
+  integration_id = opsgenie_api_integration.test.id
+  type           = "create"
+  name           = "Create high priority alerts"
+  priority       = "P2"
+  tags           = ["production", "critical"]
+  filter {
+    type = "match-all-conditions"
+    conditions {
+      field          = "tags"
+      operation      = "contains"
+      expected_value = "critical"
+    }
+  }
+}
+resource "opsgenie_integration_single_action" "close" {
+  integration_id = opsgenie_api_integration.test.id
+  type           = "close"
+  name           = "Close low priority alerts"
+  order          = 2
+  filter {
+    type = "match-all-conditions"
+    conditions {
+      field          = "priority"
+      operation      = "equals"
+      expected_value = "%s"
+    }
+  }
+}
+`, rString, priority)
+}
@@ -0,0 +1,176 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/integration"
+	"github.com/pkg/errors"
+)
+
+func TestAccOpsGenieIntegration_basic(t *testing.T) {
+	rs := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckOpsGenieIntegrationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpsGenieIntegration_basic(rs),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieIntegrationExists("opsgenie_integration.test"),
+					resource.TestCheckResourceAttr("opsgenie_integration.test", "type", "Prometheus"),
+					resource.TestCheckResourceAttrSet("opsgenie_integration.test", "api_key"),
+					resource.TestCheckResourceAttr("opsgenie_integration.test", "settings", "{}"),
+				),
+			},
+			{
+				// integrations without settings must not show a diff
+				Config:             testAccOpsGenieIntegration_basic(rs),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func TestAccOpsGenieIntegration_settings(t *testing.T) {
+	randomTeam := acctest.RandString(6)
+	randomIntegration := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckOpsGenieIntegrationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpsGenieIntegration_settings(randomTeam, randomIntegration, "ignoreTeamsFromPayload = true"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieIntegrationExists("opsgenie_integration.test"),
+					resource.TestCheckResourceAttr("opsgenie_integration.test", "settings", `{"ignoreTeamsFromPayload":true}`),
+				),
+			},
+			{
+				Config: testAccOpsGenieIntegration_settings(randomTeam, randomIntegration, "ignoreTeamsFromPayload = false"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieIntegrationExists("opsgenie_integration.test"),
+					resource.TestCheckResourceAttr("opsgenie_integration.test", "settings", `{"ignoreTeamsFromPayload":false}`),
+				),
+			},
+			{
+				Config: testAccOpsGenieIntegration_settings(randomTeam, randomIntegration, "ignoreTeamsFromPayload = true, ignoreRecipientsFromPayload = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("opsgenie_integration.test", "settings", `{"ignoreRecipientsFromPayload":true,"ignoreTeamsFromPayload":true}`),
+				),
+			},
+			{
+				// removed settings are reset in Opsgenie
+				Config: testAccOpsGenieIntegration_settings(randomTeam, randomIntegration, "ignoreTeamsFromPayload = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("opsgenie_integration.test", "settings", `{"ignoreTeamsFromPayload":true}`),
+					testCheckOpsGenieIntegrationSettingReset("opsgenie_integration.test", "ignoreRecipientsFromPayload", true),
+				),
+			},
+		},
+	})
+}
+
+func testCheckOpsGenieIntegrationDestroy(s *terraform.State) error {
+	client, err := integration.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "opsgenie_integration" {
+			continue
+		}
+		_, err := client.Get(context.Background(), &integration.GetRequest{
+			Id: rs.Primary.Attributes["id"],
+		})
+		if err != nil {
+			x := err.(*ogClient.ApiError)
+			if x.StatusCode != 404 {
+				return errors.New(fmt.Sprintf("Integration still exists : %s", x.Error()))
+			}
+		}
+	}
+	return nil
+}
+
+func testCheckOpsGenieIntegrationExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		id := rs.Primary.Attributes["id"]
+
+		client, err := integration.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+		if err != nil {
+			return err
+		}
+		_, err = client.Get(context.Background(), &integration.GetRequest{
+			Id: id,
+		})
+		if err != nil {
+			return fmt.Errorf("Bad: Integration with id %q does not exist", id)
+		}
+		return nil
+	}
+}
+
+// testCheckOpsGenieIntegrationSettingReset checks the setting of the
+// integration no longer has the value it was configured with.
+func testCheckOpsGenieIntegrationSettingReset(name, key string, configured interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		client, err := integration.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+		if err != nil {
+			return err
+		}
+		result, err := client.Get(context.Background(), &integration.GetRequest{
+			Id: rs.Primary.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if result.Data[key] == configured {
+			return fmt.Errorf("Bad: Expected %s of integration %q to be reset, got %v", key, rs.Primary.ID, result.Data[key])
+		}
+		return nil
+	}
+}
+
+func testAccOpsGenieIntegration_basic(rString string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_integration" "test" {
+  name    = "genieintegration-%s"
+  type    = "Prometheus"
+  enabled = false
+}
+`, rString)
+}
+
+func testAccOpsGenieIntegration_settings(randomTeam, randomIntegration, settings string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_team" "test" {
+  name        = "genieteam-%s"
+  description = "This team deals with all the things"
+}
+
+resource "opsgenie_integration" "test" {
+  name          = "genieintegration-%s"
+  type          = "API"
+  owner_team_id = "${opsgenie_team.test.id}"
+  settings = jsonencode({ %s })
+}
+`, randomTeam, randomIntegration, settings)
+}
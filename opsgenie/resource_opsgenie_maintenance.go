@@ -6,25 +6,38 @@ import (
 	"log"
 	"time"
 
+	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/maintenance"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceOpsgenieMaintenance() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsgenieMaintenanceCreate,
-		Read:   handleNonExistentResource(resourceOpsgenieMaintenanceRead),
-		Update: resourceOpsgenieMaintenanceUpdate,
-		Delete: resourceOpsgenieMaintenanceDelete,
+		CreateContext: resourceOpsgenieMaintenanceCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsgenieMaintenanceRead),
+		UpdateContext: resourceOpsgenieMaintenanceUpdate,
+		DeleteContext: resourceOpsgenieMaintenanceDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourceOpsgenieMaintenanceCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"description": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"keep_past_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"time": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -47,48 +60,54 @@ func resourceOpsgenieMaintenance() *schema.Resource {
 					},
 				},
 			},
-			"rules": {
-				Type:     schema.TypeList,
-				Required: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"entity": {
-							Type:     schema.TypeList,
-							Required: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"id": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									"type": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-								},
+			"rules": maintenanceRulesSchema(),
+		},
+	}
+}
+
+// maintenanceRulesSchema returns the schema of the rules of maintenances and
+// maintenance schedules.
+func maintenanceRulesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"entity": {
+					Type:     schema.TypeList,
+					Required: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"id": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"type": {
+								Type:     schema.TypeString,
+								Optional: true,
 							},
-						},
-						"state": {
-							Type:     schema.TypeString,
-							Optional: true,
 						},
 					},
 				},
+				"state": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
 			},
 		},
 	}
 }
 
-func resourceOpsgenieMaintenanceCreate(d *schema.ResourceData, meta interface{}) error {
-	client, err := maintenance.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieMaintenanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).maintenanceClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	description := d.Get("description").(string)
 
 	maintenanceTime, err := expandOpsgenieMaintenanceTime(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	createRequest := &maintenance.CreateRequest{
@@ -99,79 +118,101 @@ func resourceOpsgenieMaintenanceCreate(d *schema.ResourceData, meta interface{})
 
 	log.Printf("[INFO] Creating OpsGenie maintenance")
 
-	result, err := client.Create(context.Background(), createRequest)
+	result, err := client.Create(ctx, createRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(result.Id)
 
-	return resourceOpsgenieMaintenanceRead(d, meta)
+	return diag.FromErr(resourceOpsgenieMaintenanceRead(ctx, d, meta))
 }
 
-func resourceOpsgenieMaintenanceRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := maintenance.NewClient(meta.(*OpsgenieClient).client.Config)
-	ctx := context.Background()
-	if err != nil {
-		return err
+// resourceOpsgenieMaintenanceCustomizeDiff marks the status as changing along
+// with the time, as ending a maintenance early cancels it.
+func resourceOpsgenieMaintenanceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && d.HasChange("time") {
+		return d.SetNewComputed("status")
 	}
-	listResponse, err := client.List(ctx, &maintenance.ListRequest{})
+	return nil
+}
+
+// resourceOpsgenieMaintenanceRead gets the maintenance directly, as listing
+// maintenances leaves out past ones.
+func resourceOpsgenieMaintenanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).maintenanceClient()
 	if err != nil {
 		return err
 	}
 
-	found := maintenance.GetResult{}
-	for _, maintenanceResp := range listResponse.Maintenances {
-		if maintenanceResp.Id == d.Id() {
-			details, err := client.Get(ctx, &maintenance.GetRequest{Id: d.Id()})
-			if err != nil {
-				return err
-			}
-			found = *details
-
-			break
-		}
+	found, err := client.Get(ctx, &maintenance.GetRequest{Id: d.Id()})
+	if err != nil {
+		return err
 	}
 
-	if found.Id == "" {
-		d.SetId("")
-		log.Printf("[INFO] Maintenance not found. Removing from state")
-		return nil
+	maintenanceTime := flattenMaintenanceTime(found.Time)
+	// a maintenance cancelled by ending it early keeps the end date it was
+	// ended with, whatever end date Opsgenie shows for it
+	if endDate, err := time.Parse("2006-01-02T15:04:05Z", d.Get("time.0.end_date").(string)); err == nil &&
+		found.Status == "cancelled" && found.Time.EndDate != nil && !endDate.After(*found.Time.EndDate) {
+		maintenanceTime[0]["end_date"] = d.Get("time.0.end_date").(string)
 	}
 
-	d.Set("time", flattenMaintenanceTime(found.Time))
+	d.Set("time", maintenanceTime)
 	d.Set("description", found.Description)
 	d.Set("rules", flattenMaintenanceRules(found.Results))
+	d.Set("status", found.Status)
 
 	return nil
 }
 
-func resourceOpsgenieMaintenanceUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := maintenance.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieMaintenanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChanges("description", "time", "rules") {
+		return diag.FromErr(resourceOpsgenieMaintenanceRead(ctx, d, meta))
+	}
+
+	client, err := meta.(*OpsgenieClient).maintenanceClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	mnt, err := client.Get(context.Background(), &maintenance.GetRequest{
+	mnt, err := client.Get(ctx, &maintenance.GetRequest{
 		Id: d.Id(),
 	})
 	if err != nil {
 		log.Printf("[ERROR] Maintenance could not fetch")
-		return err
+		return diag.FromErr(err)
 
 	}
 	maintenanceTime, err := expandOpsgenieMaintenanceTime(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if mnt.Status == "active" {
+		// only the end of active maintenances can be changed, ending them
+		// early cancels them so they are kept in the history
+		if d.HasChanges("description", "rules", "time.0.type", "time.0.start_date") {
+			return diag.Errorf("Only the end date of active maintenances can be changed")
+		}
 
-		_, err := client.ChangeEndDate(context.Background(), &maintenance.ChangeEndDateRequest{
-			Id:      d.Id(),
-			EndDate: maintenanceTime.EndDate,
-		})
+		switch {
+		case maintenanceTime.EndDate == nil:
+			// maintenances which aren't scheduled have no end date to change
+			log.Printf("[INFO] Keeping the end date of OpsGenie maintenance")
+		case !maintenanceTime.EndDate.After(time.Now()):
+			log.Printf("[INFO] Cancelling OpsGenie maintenance")
+			_, err = client.Cancel(ctx, &maintenance.CancelRequest{
+				Id: d.Id(),
+			})
+		default:
+			log.Printf("[INFO] Changing end date of OpsGenie maintenance")
+			_, err = client.ChangeEndDate(ctx, &maintenance.ChangeEndDateRequest{
+				Id:      d.Id(),
+				EndDate: maintenanceTime.EndDate,
+			})
+		}
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 	} else if mnt.Status == "planned" {
@@ -186,36 +227,67 @@ func resourceOpsgenieMaintenanceUpdate(d *schema.ResourceData, meta interface{})
 
 		log.Printf("[INFO] Updating OpsGenie maintenance")
 
-		_, err = client.Update(context.Background(), updateRequest)
+		_, err = client.Update(ctx, updateRequest)
 		if err != nil {
 			log.Printf("%s", err.Error())
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		log.Printf("[ERROR] You cannot edit past maintenance")
-		return errors.New("You cannot edit" + mnt.Status + " maintenances")
+		return diag.Errorf("You cannot edit %s maintenances", mnt.Status)
 
 	}
 
-	return nil
+	return diag.FromErr(resourceOpsgenieMaintenanceRead(ctx, d, meta))
 }
 
-func resourceOpsgenieMaintenanceDelete(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("[INFO] Deleting OpsGenie escalation ")
-	client, err := maintenance.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieMaintenanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Deleting OpsGenie maintenance")
+	client, err := meta.(*OpsgenieClient).maintenanceClient()
 	if err != nil {
-		return err
-	}
-	deleteRequest := &maintenance.DeleteRequest{
-		Id: d.Id(),
+		return diag.FromErr(err)
 	}
 
-	_, err = client.Delete(context.Background(), deleteRequest)
+	return diag.FromErr(deleteOpsgenieMaintenance(ctx, client, d.Id(), d.Get("keep_past_on_destroy").(bool)))
+}
+
+// deleteOpsgenieMaintenance cancels active maintenances before deleting them.
+// Past and cancelled maintenances are kept instead of deleted if keepPast is
+// set.
+func deleteOpsgenieMaintenance(ctx context.Context, client *maintenance.Client, id string, keepPast bool) error {
+	mnt, err := client.Get(ctx, &maintenance.GetRequest{
+		Id: id,
+	})
 	if err != nil {
+		if apiErr, ok := err.(*ogClient.ApiError); ok && apiErr.StatusCode == 404 {
+			return nil
+		}
 		return err
 	}
 
-	return nil
+	status := mnt.Status
+	if status == "active" {
+		log.Printf("[INFO] Cancelling active OpsGenie maintenance %s", id)
+		_, err = client.Cancel(ctx, &maintenance.CancelRequest{
+			Id: id,
+		})
+		if err != nil {
+			return err
+		}
+		status = "cancelled"
+	}
+
+	if keepPast && (status == "past" || status == "cancelled") {
+		log.Printf("[INFO] Keeping %s OpsGenie maintenance %s", status, id)
+		return nil
+	}
+
+	deleteRequest := &maintenance.DeleteRequest{
+		Id: id,
+	}
+
+	_, err = client.Delete(ctx, deleteRequest)
+	return err
 }
 
 func expandOpsgenieMaintenanceRules(d *schema.ResourceData) []maintenance.Rule {
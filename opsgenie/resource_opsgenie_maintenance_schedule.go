@@ -0,0 +1,447 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/maintenance"
+)
+
+const maintenanceDateLayout = "2006-01-02T15:04:05Z"
+
+var maintenanceWeekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// resourceOpsgenieMaintenanceSchedule manages recurring maintenances. Opsgenie
+// has no recurring maintenances, so the next windows of the recurrence are
+// created as one-off maintenances, and recreated as they pass on every apply.
+func resourceOpsgenieMaintenanceSchedule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOpsgenieMaintenanceScheduleCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsgenieMaintenanceScheduleRead),
+		UpdateContext: resourceOpsgenieMaintenanceScheduleUpdate,
+		DeleteContext: resourceOpsgenieMaintenanceScheduleDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourceOpsgenieMaintenanceScheduleCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"recurrence": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"frequency": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"weekly", "monthly"}, false),
+						},
+						"weekday": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}, false),
+						},
+						"week_of_month": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntInSlice([]int{1, 2, 3, 4, -1}),
+						},
+						"start_time": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`), "must be a time of day like 22:30"),
+						},
+						"duration": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateMaintenanceScheduleDuration,
+						},
+						"timezone": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "UTC",
+							ValidateFunc: validateMaintenanceScheduleTimezone,
+						},
+						"horizon": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      4,
+							ValidateFunc: validation.IntBetween(1, 52),
+						},
+					},
+				},
+			},
+			"rules": maintenanceRulesSchema(),
+			"windows": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"start_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"end_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// maintenanceRecurrence is the recurrence of a maintenance schedule.
+type maintenanceRecurrence struct {
+	frequency   string
+	weekday     time.Weekday
+	weekOfMonth int
+	hour        int
+	minute      int
+	duration    time.Duration
+	location    *time.Location
+	horizon     int
+}
+
+// maintenanceWindow is a maintenance created for an occurrence of a
+// recurrence.
+type maintenanceWindow struct {
+	id        string
+	startDate time.Time
+	endDate   time.Time
+	status    string
+}
+
+func validateMaintenanceScheduleDuration(v interface{}, k string) (ws []string, errors []error) {
+	duration, err := time.ParseDuration(v.(string))
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a duration like 2h30m: %s", k, err))
+	} else if duration < time.Minute {
+		errors = append(errors, fmt.Errorf("%q must be at least a minute: %q", k, v.(string)))
+	}
+	return
+}
+
+func validateMaintenanceScheduleTimezone(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := time.LoadLocation(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a timezone like Europe/Berlin: %s", k, err))
+	}
+	return
+}
+
+func expandOpsgenieMaintenanceRecurrence(input []interface{}) (maintenanceRecurrence, error) {
+	recurrence := maintenanceRecurrence{}
+	for _, v := range input {
+		config := v.(map[string]interface{})
+		recurrence.frequency = config["frequency"].(string)
+		recurrence.weekday = maintenanceWeekdays[config["weekday"].(string)]
+		recurrence.weekOfMonth = config["week_of_month"].(int)
+		recurrence.horizon = config["horizon"].(int)
+
+		startTime, err := time.Parse("15:04", config["start_time"].(string))
+		if err != nil {
+			return recurrence, err
+		}
+		recurrence.hour, recurrence.minute = startTime.Hour(), startTime.Minute()
+
+		if recurrence.duration, err = time.ParseDuration(config["duration"].(string)); err != nil {
+			return recurrence, err
+		}
+		if recurrence.location, err = time.LoadLocation(config["timezone"].(string)); err != nil {
+			return recurrence, err
+		}
+	}
+	return recurrence, nil
+}
+
+// occurrences returns the next horizon occurrences of the recurrence which
+// haven't ended at now, including an ongoing one.
+func (r maintenanceRecurrence) occurrences(now time.Time) []maintenanceWindow {
+	windows := make([]maintenanceWindow, 0, r.horizon)
+	// occurrences starting up to a duration ago may still be ongoing
+	from := now.Add(-r.duration).In(r.location)
+
+	switch r.frequency {
+	case "weekly":
+		day := time.Date(from.Year(), from.Month(), from.Day(), r.hour, r.minute, 0, 0, r.location)
+		day = day.AddDate(0, 0, (int(r.weekday)-int(day.Weekday())+7)%7)
+		for ; len(windows) < r.horizon; day = day.AddDate(0, 0, 7) {
+			windows = r.appendOccurrence(windows, day, now)
+		}
+	case "monthly":
+		for month := 0; len(windows) < r.horizon; month++ {
+			windows = r.appendOccurrence(windows, r.monthlyOccurrence(from.Year(), from.Month()+time.Month(month)), now)
+		}
+	}
+	return windows
+}
+
+func (r maintenanceRecurrence) appendOccurrence(windows []maintenanceWindow, start time.Time, now time.Time) []maintenanceWindow {
+	end := start.Add(r.duration)
+	if !end.After(now) {
+		return windows
+	}
+	return append(windows, maintenanceWindow{startDate: start.UTC(), endDate: end.UTC()})
+}
+
+// monthlyOccurrence returns the start of the occurrence in the given month,
+// which is on the week_of_month'th weekday of the month, -1 being the last.
+func (r maintenanceRecurrence) monthlyOccurrence(year int, month time.Month) time.Time {
+	if r.weekOfMonth < 0 {
+		last := time.Date(year, month+1, 0, r.hour, r.minute, 0, 0, r.location)
+		return last.AddDate(0, 0, -((int(last.Weekday()) - int(r.weekday) + 7) % 7))
+	}
+	first := time.Date(year, month, 1, r.hour, r.minute, 0, 0, r.location)
+	return first.AddDate(0, 0, (int(r.weekday)-int(first.Weekday())+7)%7+7*(r.weekOfMonth-1))
+}
+
+// resourceOpsgenieMaintenanceScheduleCustomizeDiff plans the creation of new
+// windows whenever a window passed or the occurrences of the recurrence
+// changed, so every apply keeps the next windows created.
+func resourceOpsgenieMaintenanceScheduleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("recurrence") {
+		return nil
+	}
+	recurrence, err := expandOpsgenieMaintenanceRecurrence(d.Get("recurrence").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	windows := expandOpsgenieMaintenanceWindows(d.Get("windows").([]interface{}))
+	occurrences := recurrence.occurrences(time.Now())
+	if d.HasChange("description") || d.HasChange("rules") || len(windows) != len(occurrences) {
+		return d.SetNewComputed("windows")
+	}
+	for i, w := range windows {
+		if (w.status != "planned" && w.status != "active") || !w.startDate.Equal(occurrences[i].startDate) || !w.endDate.Equal(occurrences[i].endDate) {
+			return d.SetNewComputed("windows")
+		}
+	}
+	return nil
+}
+
+func resourceOpsgenieMaintenanceScheduleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(resource.PrefixedUniqueId("maintenance-schedule-"))
+
+	if err := syncOpsgenieMaintenanceWindows(ctx, d, meta, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(resourceOpsgenieMaintenanceScheduleRead(ctx, d, meta))
+}
+
+// resourceOpsgenieMaintenanceScheduleRead refreshes the windows, leaving out
+// the ones deleted outside of Terraform.
+func resourceOpsgenieMaintenanceScheduleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).maintenanceClient()
+	if err != nil {
+		return err
+	}
+
+	windows := make([]maintenanceWindow, 0)
+	for _, w := range expandOpsgenieMaintenanceWindows(d.Get("windows").([]interface{})) {
+		result, err := client.Get(ctx, &maintenance.GetRequest{Id: w.id})
+		if err != nil {
+			if apiErr, ok := err.(*ogClient.ApiError); ok && apiErr.StatusCode == 404 {
+				log.Printf("[WARN] Maintenance window %s of maintenance schedule %s is gone", w.id, d.Id())
+				continue
+			}
+			return err
+		}
+		w.status = result.Status
+		windows = append(windows, w)
+	}
+
+	d.Set("windows", flattenOpsgenieMaintenanceWindows(windows))
+
+	return nil
+}
+
+func resourceOpsgenieMaintenanceScheduleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	oldWindows, _ := d.GetChange("windows")
+	if err := syncOpsgenieMaintenanceWindows(ctx, d, meta, expandOpsgenieMaintenanceWindows(oldWindows.([]interface{}))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(resourceOpsgenieMaintenanceScheduleRead(ctx, d, meta))
+}
+
+func resourceOpsgenieMaintenanceScheduleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).maintenanceClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, w := range expandOpsgenieMaintenanceWindows(d.Get("windows").([]interface{})) {
+		log.Printf("[INFO] Deleting maintenance window %s of maintenance schedule %s", w.id, d.Id())
+		if err := deleteOpsgenieMaintenance(ctx, client, w.id, false); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// syncOpsgenieMaintenanceWindows makes the windows of the schedule match the
+// next occurrences of its recurrence. Windows which passed or no longer match
+// an occurrence are deleted, windows of occurrences missing one are created.
+func syncOpsgenieMaintenanceWindows(ctx context.Context, d *schema.ResourceData, meta interface{}, windows []maintenanceWindow) error {
+	client, err := meta.(*OpsgenieClient).maintenanceClient()
+	if err != nil {
+		return err
+	}
+	recurrence, err := expandOpsgenieMaintenanceRecurrence(d.Get("recurrence").([]interface{}))
+	if err != nil {
+		return err
+	}
+	description := d.Get("description").(string)
+	rules := expandOpsgenieMaintenanceRules(d)
+	now := time.Now()
+
+	kept := make(map[int64]maintenanceWindow)
+	// failed keeps track of the windows kept so far and the ones not checked
+	// yet, as the windows are unknown when syncing fails during an update
+	failed := func(unchecked []maintenanceWindow, err error) error {
+		remaining := append([]maintenanceWindow{}, unchecked...)
+		for _, w := range kept {
+			remaining = append(remaining, w)
+		}
+		sort.Slice(remaining, func(i, j int) bool { return remaining[i].startDate.Before(remaining[j].startDate) })
+		d.Set("windows", flattenOpsgenieMaintenanceWindows(remaining))
+		return err
+	}
+	for i, w := range windows {
+		result, err := client.Get(ctx, &maintenance.GetRequest{Id: w.id})
+		if err != nil {
+			if apiErr, ok := err.(*ogClient.ApiError); ok && apiErr.StatusCode == 404 {
+				continue
+			}
+			return failed(windows[i:], err)
+		}
+		w.status = result.Status
+
+		if _, duplicate := kept[w.startDate.Unix()]; !duplicate && recurrence.hasOccurrence(w, now) && (w.status == "planned" || w.status == "active") {
+			if w.status == "planned" && d.HasChanges("description", "rules") {
+				log.Printf("[INFO] Updating maintenance window %s of maintenance schedule %s", w.id, d.Id())
+				_, err := client.Update(ctx, &maintenance.UpdateRequest{
+					Id:          w.id,
+					Description: description,
+					Rules:       rules,
+					Time:        maintenance.Time{Type: maintenance.Schedule, StartDate: &w.startDate, EndDate: &w.endDate},
+				})
+				if err != nil {
+					return failed(windows[i:], err)
+				}
+			}
+			kept[w.startDate.Unix()] = w
+			continue
+		}
+
+		log.Printf("[INFO] Deleting maintenance window %s of maintenance schedule %s", w.id, d.Id())
+		if err := deleteOpsgenieMaintenance(ctx, client, w.id, false); err != nil {
+			return failed(windows[i:], err)
+		}
+	}
+
+	synced := make([]maintenanceWindow, 0, recurrence.horizon)
+	for _, occurrence := range recurrence.occurrences(now) {
+		if w, ok := kept[occurrence.startDate.Unix()]; ok {
+			synced = append(synced, w)
+			continue
+		}
+
+		log.Printf("[INFO] Creating maintenance window at %s of maintenance schedule %s", occurrence.startDate.Format(maintenanceDateLayout), d.Id())
+		startDate, endDate := occurrence.startDate, occurrence.endDate
+		result, err := client.Create(ctx, &maintenance.CreateRequest{
+			Description: description,
+			Rules:       rules,
+			Time:        maintenance.Time{Type: maintenance.Schedule, StartDate: &startDate, EndDate: &endDate},
+		})
+		if err != nil {
+			// keep track of the windows created so far and the ones left
+			for _, w := range kept {
+				if w.startDate.After(occurrence.startDate) {
+					synced = append(synced, w)
+				}
+			}
+			sort.Slice(synced, func(i, j int) bool { return synced[i].startDate.Before(synced[j].startDate) })
+			d.Set("windows", flattenOpsgenieMaintenanceWindows(synced))
+			return err
+		}
+		occurrence.id = result.Id
+		synced = append(synced, occurrence)
+	}
+
+	d.Set("windows", flattenOpsgenieMaintenanceWindows(synced))
+	return nil
+}
+
+// hasOccurrence tells whether w is the window of one of the next occurrences.
+func (r maintenanceRecurrence) hasOccurrence(w maintenanceWindow, now time.Time) bool {
+	for _, occurrence := range r.occurrences(now) {
+		if occurrence.startDate.Equal(w.startDate) && occurrence.endDate.Equal(w.endDate) {
+			return true
+		}
+	}
+	return false
+}
+
+func expandOpsgenieMaintenanceWindows(input []interface{}) []maintenanceWindow {
+	windows := make([]maintenanceWindow, 0, len(input))
+	for _, v := range input {
+		config, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		w := maintenanceWindow{
+			id:     config["id"].(string),
+			status: config["status"].(string),
+		}
+		w.startDate, _ = time.Parse(maintenanceDateLayout, config["start_date"].(string))
+		w.endDate, _ = time.Parse(maintenanceDateLayout, config["end_date"].(string))
+		windows = append(windows, w)
+	}
+	return windows
+}
+
+func flattenOpsgenieMaintenanceWindows(windows []maintenanceWindow) []map[string]interface{} {
+	output := make([]map[string]interface{}, 0, len(windows))
+	for _, w := range windows {
+		output = append(output, map[string]interface{}{
+			"id":         w.id,
+			"start_date": w.startDate.UTC().Format(maintenanceDateLayout),
+			"end_date":   w.endDate.UTC().Format(maintenanceDateLayout),
+			"status":     w.status,
+		})
+	}
+	return output
+}
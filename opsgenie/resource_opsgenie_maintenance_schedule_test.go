@@ -0,0 +1,262 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/maintenance"
+)
+
+func TestMaintenanceRecurrenceOccurrences(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("Timezone data is not available: ", err)
+	}
+	// a wednesday
+	now := time.Date(2021, 3, 24, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name       string
+		recurrence maintenanceRecurrence
+		expected   []string
+	}{
+		{
+			name:       "weekly",
+			recurrence: maintenanceRecurrence{frequency: "weekly", weekday: time.Friday, hour: 22, duration: 2 * time.Hour, location: time.UTC, horizon: 2},
+			expected:   []string{"2021-03-26T22:00:00Z", "2021-04-02T22:00:00Z"},
+		},
+		{
+			name:       "weekly ongoing",
+			recurrence: maintenanceRecurrence{frequency: "weekly", weekday: time.Wednesday, hour: 11, minute: 30, duration: time.Hour, location: time.UTC, horizon: 2},
+			expected:   []string{"2021-03-24T11:30:00Z", "2021-03-31T11:30:00Z"},
+		},
+		{
+			name:       "weekly ended",
+			recurrence: maintenanceRecurrence{frequency: "weekly", weekday: time.Wednesday, hour: 10, duration: time.Hour, location: time.UTC, horizon: 1},
+			expected:   []string{"2021-03-31T10:00:00Z"},
+		},
+		{
+			name:       "weekly across daylight saving time",
+			recurrence: maintenanceRecurrence{frequency: "weekly", weekday: time.Saturday, hour: 23, duration: time.Hour, location: berlin, horizon: 2},
+			expected:   []string{"2021-03-27T22:00:00Z", "2021-04-03T21:00:00Z"},
+		},
+		{
+			name:       "monthly",
+			recurrence: maintenanceRecurrence{frequency: "monthly", weekday: time.Tuesday, weekOfMonth: 2, hour: 6, duration: 4 * time.Hour, location: time.UTC, horizon: 2},
+			expected:   []string{"2021-04-13T06:00:00Z", "2021-05-11T06:00:00Z"},
+		},
+		{
+			name:       "monthly last weekday",
+			recurrence: maintenanceRecurrence{frequency: "monthly", weekday: time.Sunday, weekOfMonth: -1, hour: 2, duration: time.Hour, location: time.UTC, horizon: 3},
+			expected:   []string{"2021-03-28T02:00:00Z", "2021-04-25T02:00:00Z", "2021-05-30T02:00:00Z"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			occurrences := c.recurrence.occurrences(now)
+			if len(occurrences) != len(c.expected) {
+				t.Fatalf("Expected %d occurrences, got %d", len(c.expected), len(occurrences))
+			}
+			for i, occurrence := range occurrences {
+				if start := occurrence.startDate.Format(maintenanceDateLayout); start != c.expected[i] {
+					t.Errorf("Expected occurrence %d to start at %s, got %s", i, c.expected[i], start)
+				}
+				if !occurrence.endDate.Equal(occurrence.startDate.Add(c.recurrence.duration)) {
+					t.Errorf("Expected occurrence %d to last %s, got %s", i, c.recurrence.duration, occurrence.endDate.Sub(occurrence.startDate))
+				}
+			}
+		})
+	}
+}
+
+func TestSyncOpsgenieMaintenanceWindows_failure(t *testing.T) {
+	api, client := testFakeOpsgenieClient(t)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceOpsgenieMaintenanceSchedule().Schema, map[string]interface{}{
+		"description": "geniemaintenance-fake",
+		"recurrence": []interface{}{map[string]interface{}{
+			"frequency":  "weekly",
+			"weekday":    "sunday",
+			"start_time": "22:00",
+			"duration":   "2h",
+		}},
+		"rules": []interface{}{map[string]interface{}{
+			"state": "disabled",
+			"entity": []interface{}{map[string]interface{}{
+				"id":   "integration-id",
+				"type": "integration",
+			}},
+		}},
+	})
+	if diags := resourceOpsgenieMaintenanceScheduleCreate(ctx, d, client); diags.HasError() {
+		t.Fatal(diags)
+	}
+	windows := expandOpsgenieMaintenanceWindows(d.Get("windows").([]interface{}))
+	if len(windows) != 4 {
+		t.Fatalf("Expected 4 windows, got %d", len(windows))
+	}
+
+	// the windows of a failed update must not be lost
+	d.Set("windows", nil)
+	api.apiKey = "revoked"
+	if err := syncOpsgenieMaintenanceWindows(ctx, d, client, windows); err == nil {
+		t.Fatal("Expected syncing to fail")
+	}
+	kept := expandOpsgenieMaintenanceWindows(d.Get("windows").([]interface{}))
+	if len(kept) != len(windows) {
+		t.Fatalf("Expected %d windows to be kept, got %d", len(windows), len(kept))
+	}
+	for i := range windows {
+		if kept[i].id != windows[i].id {
+			t.Errorf("Expected window %d to be %s, got %s", i, windows[i].id, kept[i].id)
+		}
+	}
+}
+
+func TestAccOpsGenieMaintenanceSchedule_basic(t *testing.T) {
+	randomName := acctest.RandString(6)
+	var firstWindowId string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckOpsGenieMaintenanceScheduleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpsGenieMaintenanceSchedule_basic(randomName, "22:00", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("opsgenie_maintenance_schedule.test", "windows.#", "2"),
+					testCheckOpsGenieMaintenanceScheduleWindowsExist("opsgenie_maintenance_schedule.test"),
+					func(s *terraform.State) error {
+						firstWindowId = s.RootModule().Resources["opsgenie_maintenance_schedule.test"].Primary.Attributes["windows.0.id"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccOpsGenieMaintenanceSchedule_basic(randomName, "22:00", 3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("opsgenie_maintenance_schedule.test", "windows.#", "3"),
+					resource.TestCheckResourceAttrPtr("opsgenie_maintenance_schedule.test", "windows.0.id", &firstWindowId),
+					testCheckOpsGenieMaintenanceScheduleWindowsExist("opsgenie_maintenance_schedule.test"),
+				),
+			},
+			{
+				// windows deleted outside of Terraform are created again
+				PreConfig: func() {
+					client, _ := maintenance.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+					client.Delete(context.Background(), &maintenance.DeleteRequest{Id: firstWindowId})
+				},
+				Config: testAccOpsGenieMaintenanceSchedule_basic(randomName, "22:00", 3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("opsgenie_maintenance_schedule.test", "windows.#", "3"),
+					testCheckOpsGenieMaintenanceScheduleWindowsExist("opsgenie_maintenance_schedule.test"),
+				),
+			},
+			{
+				Config: testAccOpsGenieMaintenanceSchedule_basic(randomName, "23:30", 3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("opsgenie_maintenance_schedule.test", "windows.#", "3"),
+					resource.TestMatchResourceAttr("opsgenie_maintenance_schedule.test", "windows.0.start_date", regexpMustMatchTime("23:30")),
+					testCheckOpsGenieMaintenanceScheduleWindowsExist("opsgenie_maintenance_schedule.test"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckOpsGenieMaintenanceScheduleDestroy(s *terraform.State) error {
+	client, err := maintenance.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "opsgenie_maintenance_schedule" {
+			continue
+		}
+		for _, id := range testMaintenanceScheduleWindowIds(rs) {
+			_, err := client.Get(context.Background(), &maintenance.GetRequest{Id: id})
+			if err == nil {
+				return fmt.Errorf("Maintenance window %s still exists", id)
+			}
+			if apiErr, ok := err.(*ogClient.ApiError); !ok || apiErr.StatusCode != 404 {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func testCheckOpsGenieMaintenanceScheduleWindowsExist(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		client, err := maintenance.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+		if err != nil {
+			return err
+		}
+		for _, id := range testMaintenanceScheduleWindowIds(rs) {
+			result, err := client.Get(context.Background(), &maintenance.GetRequest{Id: id})
+			if err != nil {
+				return fmt.Errorf("Bad: Maintenance window %q does not exist", id)
+			}
+			if result.Status != "planned" && result.Status != "active" {
+				return fmt.Errorf("Bad: Maintenance window %q is %s", id, result.Status)
+			}
+		}
+		return nil
+	}
+}
+
+func testMaintenanceScheduleWindowIds(rs *terraform.ResourceState) []string {
+	var ids []string
+	for i := 0; ; i++ {
+		id, ok := rs.Primary.Attributes[fmt.Sprintf("windows.%d.id", i)]
+		if !ok {
+			return ids
+		}
+		ids = append(ids, id)
+	}
+}
+
+func regexpMustMatchTime(hourMinute string) *regexp.Regexp {
+	return regexp.MustCompile(fmt.Sprintf(`T%s:00Z$`, hourMinute))
+}
+
+func testAccOpsGenieMaintenanceSchedule_basic(randomName, startTime string, horizon int) string {
+	return fmt.Sprintf(`
+resource "opsgenie_email_integration" "test" {
+  name           = "testemailapi-maintenance-%s"
+  email_username = "user-%s"
+}
+resource "opsgenie_maintenance_schedule" "test" {
+  description = "geniemaintenance-%s"
+  recurrence {
+    frequency  = "weekly"
+    weekday    = "sunday"
+    start_time = "%s"
+    duration   = "2h"
+    horizon    = %d
+  }
+  rules {
+    state = "disabled"
+    entity {
+      id   = opsgenie_email_integration.test.id
+      type = "integration"
+    }
+  }
+}
+`, randomName, randomName, randomName, startTime, horizon)
+}
@@ -76,6 +76,66 @@ func TestAccOpsGenieMaintenance_complete(t *testing.T) {
 	})
 }
 
+func TestAccOpsGenieMaintenance_lifecycle(t *testing.T) {
+	randomName := acctest.RandString(6)
+	randomMaintenance := acctest.RandString(6)
+	nextYear := time.Now().Year() + 1
+	var maintenanceId string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testCheckOpsGenieMaintenanceKept(maintenanceId, "cancelled")
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpsGenieMaintenance_lifecycle(randomName, randomMaintenance, fmt.Sprintf("%04d-01-01T00:00:00Z", nextYear)),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieMaintenanceExists("opsgenie_maintenance.test"),
+					resource.TestCheckResourceAttr("opsgenie_maintenance.test", "status", "active"),
+					func(s *terraform.State) error {
+						maintenanceId = s.RootModule().Resources["opsgenie_maintenance.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccOpsGenieMaintenance_lifecycle(randomName, randomMaintenance, fmt.Sprintf("%04d-06-01T00:00:00Z", nextYear)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("opsgenie_maintenance.test", "id", &maintenanceId),
+					resource.TestCheckResourceAttr("opsgenie_maintenance.test", "status", "active"),
+					resource.TestCheckResourceAttr("opsgenie_maintenance.test", "time.0.end_date", fmt.Sprintf("%04d-06-01T00:00:00Z", nextYear)),
+				),
+			},
+			{
+				Config: testAccOpsGenieMaintenance_lifecycle(randomName, randomMaintenance, "2020-01-01T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("opsgenie_maintenance.test", "id", &maintenanceId),
+					resource.TestCheckResourceAttr("opsgenie_maintenance.test", "status", "cancelled"),
+					resource.TestCheckResourceAttr("opsgenie_maintenance.test", "time.0.end_date", "2020-01-01T00:00:00Z"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckOpsGenieMaintenanceKept(id, status string) error {
+	client, err := maintenance.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+	if err != nil {
+		return err
+	}
+	result, err := client.Get(context.Background(), &maintenance.GetRequest{
+		Id: id,
+	})
+	if err != nil {
+		return fmt.Errorf("Maintenance %s was not kept: %s", id, err)
+	}
+	if result.Status != status {
+		return fmt.Errorf("Expected kept maintenance %s to be %s, got %s", id, status, result.Status)
+	}
+	return nil
+}
+
 func testCheckOpsGenieMaintenanceDestroy(s *terraform.State) error {
 	client, err := maintenance.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
 	if err != nil {
@@ -152,3 +212,28 @@ resource "opsgenie_maintenance" "test" {
 }
 `, randomName, randomName, randomMaintenance, time.Now().Year()+1, time.Now().Month(), time.Now().Day())
 }
+
+func testAccOpsGenieMaintenance_lifecycle(randomName, randomMaintenance, endDate string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_email_integration" "test" {
+  name = "testemailapi-maintenance-%s"
+  email_username ="user-%s"
+}
+resource "opsgenie_maintenance" "test" {
+  description          = "geniemaintenance-%s"
+  keep_past_on_destroy = true
+  time {
+    type       = "schedule"
+    start_date = "2019-06-20T17:45:00Z"
+    end_date   = "%s"
+  }
+  rules {
+    state = "disabled"
+    entity {
+      id   = opsgenie_email_integration.test.id
+      type = "integration"
+    }
+  }
+}
+`, randomName, randomName, randomMaintenance, endDate)
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
@@ -38,12 +39,14 @@ func durationSchema(required bool, description string) *schema.Schema {
 
 func resourceOpsGenieNotificationPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsGenieNotificationPolicyCreate,
-		Read:   handleNonExistentResource(resourceOpsGenieNotificationPolicyRead),
-		Update: resourceOpsGenieNotificationPolicyUpdate,
-		Delete: resourceOpsGenieNotificationPolicyDelete,
+		CreateContext: resourceOpsGenieNotificationPolicyCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsGenieNotificationPolicyRead),
+		UpdateContext: resourceOpsGenieNotificationPolicyUpdate,
+		DeleteContext: resourceOpsGenieNotificationPolicyDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: validateConditionsDiff(conditionsBlock{path: []string{"filter"}, matchType: "type"}),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idParts := strings.Split(d.Id(), "/")
 				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected team_id/notification_policy_id", d.Id())
@@ -83,53 +86,9 @@ func resourceOpsGenieNotificationPolicy() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Default:      "match-all",
-							ValidateFunc: validation.StringInSlice([]string{"match-all", "match-any-condition", "match-all-conditions"}, false),
-						},
-						"conditions": {
-							Type:     schema.TypeSet,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"field": {
-										Type:     schema.TypeString,
-										Required: true,
-										ValidateFunc: validation.StringInSlice([]string{
-											"message", "alias", "description", "source", "entity", "tags",
-											"actions", "details", "extra-properties", "responders", "teams", "priority",
-										}, false),
-									},
-									"operation": {
-										Type:     schema.TypeString,
-										Required: true,
-										ValidateFunc: validation.StringInSlice([]string{
-											"matches", "contains", "starts-with", "ends-with", "equals", "contains-key",
-											"contains-value", "greater-than", "less-than", "is-empty", "equals-ignore-whitespace",
-										}, false),
-									},
-									"key": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "If 'field' is set as 'extra-properties', key could be used for key-value pair",
-									},
-									"not": {
-										Type:        schema.TypeBool,
-										Optional:    true,
-										Description: "Indicates behaviour of the given operation. Default value is false",
-										Default:     false,
-									},
-									"expected_value": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "User defined value that will be compared with alert field according to the operation. Default value is empty string",
-									},
-									"order": {
-										Type:        schema.TypeInt,
-										Optional:    true,
-										Description: "Order of the condition in conditions list",
-									},
-								},
-							},
+							ValidateFunc: validation.StringInSlice(conditionMatchTypes, false),
 						},
+						"conditions": conditionsSchema(schema.TypeSet, policyConditionFields),
 					},
 				},
 			},
@@ -216,18 +175,18 @@ func resourceOpsGenieNotificationPolicy() *schema.Resource {
 	}
 }
 
-func resourceOpsGenieNotificationPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsGenieNotificationPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := resourceOpsGenieNotificationPolicyMultiValueValidation(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = resourceOpsGenieNotificationPolicySuppressDelayActionDeDuplicationActionValidation(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	client, err := policy.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).policyClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	suppress := d.Get("suppress").(bool)
@@ -250,18 +209,18 @@ func resourceOpsGenieNotificationPolicyCreate(d *schema.ResourceData, meta inter
 	}
 
 	log.Printf("[INFO] Creating Notification Policy '%s'", d.Get("name").(string))
-	result, err := client.CreateNotificationPolicy(context.Background(), createRequest)
+	result, err := client.CreateNotificationPolicy(ctx, createRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(result.Id)
 
-	return resourceOpsGenieNotificationPolicyRead(d, meta)
+	return diag.FromErr(resourceOpsGenieNotificationPolicyRead(ctx, d, meta))
 }
 
-func resourceOpsGenieNotificationPolicyRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := policy.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieNotificationPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).policyClient()
 	if err != nil {
 		return err
 	}
@@ -269,7 +228,7 @@ func resourceOpsGenieNotificationPolicyRead(d *schema.ResourceData, meta interfa
 
 	log.Printf("[INFO] Reading OpsGenie Notification Policy '%s'", name)
 
-	policy, err := client.GetNotificationPolicy(context.Background(), &policy.GetNotificationPolicyRequest{
+	policy, err := client.GetNotificationPolicy(ctx, &policy.GetNotificationPolicyRequest{
 		Id:     d.Id(),
 		TeamId: d.Get("team_id").(string),
 	})
@@ -323,18 +282,18 @@ func resourceOpsGenieNotificationPolicyRead(d *schema.ResourceData, meta interfa
 	return nil
 }
 
-func resourceOpsGenieNotificationPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsGenieNotificationPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := resourceOpsGenieNotificationPolicyMultiValueValidation(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = resourceOpsGenieNotificationPolicySuppressDelayActionDeDuplicationActionValidation(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	client, err := policy.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).policyClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	suppress := d.Get("suppress").(bool)
@@ -358,19 +317,19 @@ func resourceOpsGenieNotificationPolicyUpdate(d *schema.ResourceData, meta inter
 	}
 
 	log.Printf("[INFO] Updating Notification Policy '%s'", d.Get("name").(string))
-	_, err = client.UpdateNotificationPolicy(context.Background(), updateRequest)
+	_, err = client.UpdateNotificationPolicy(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceOpsGenieNotificationPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsGenieNotificationPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Deleting OpsGenie Notification Policy '%s'", d.Get("name").(string))
-	client, err := policy.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).policyClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	deleteRequest := &policy.DeletePolicyRequest{
 		Id:     d.Id(),
@@ -378,9 +337,9 @@ func resourceOpsGenieNotificationPolicyDelete(d *schema.ResourceData, meta inter
 		Type:   "notification",
 	}
 
-	_, err = client.DeletePolicy(context.Background(), deleteRequest)
+	_, err = client.DeletePolicy(ctx, deleteRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -528,33 +487,11 @@ func expandOpsGenieNotificationPolicyFilter(input []interface{}) *og.Filter {
 	for _, v := range input {
 		config := v.(map[string]interface{})
 		filter.ConditionMatchType = og.ConditionMatchType(config["type"].(string))
-		filter.Conditions = expandOpsGenieNotificationPolicyFilterConditions(config["conditions"].(*schema.Set))
+		filter.Conditions = expandOpsgenieConditions(config["conditions"].(*schema.Set).List())
 	}
 	return &filter
 }
 
-func expandOpsGenieNotificationPolicyFilterConditions(input *schema.Set) []og.Condition {
-	conditions := make([]og.Condition, 0, input.Len())
-	condition := og.Condition{}
-	if input == nil {
-		return conditions
-	}
-
-	for _, v := range input.List() {
-		config := v.(map[string]interface{})
-		not_value := config["not"].(bool)
-		order := config["order"].(int)
-		condition.Field = og.ConditionFieldType(config["field"].(string))
-		condition.Operation = og.ConditionOperation(config["operation"].(string))
-		condition.Key = config["key"].(string)
-		condition.IsNot = &not_value
-		condition.ExpectedValue = config["expected_value"].(string)
-		condition.Order = &order
-		conditions = append(conditions, condition)
-	}
-	return conditions
-}
-
 func flattenOpsGenieNotificationPolicyDuration(input *policy.Duration) []map[string]interface{} {
 	output := make([]map[string]interface{}, 0, 1)
 	element := make(map[string]interface{})
@@ -614,26 +551,10 @@ func flattenOpsGenieNotificationPolicyFilter(input *og.Filter) []map[string]inte
 	output := make([]map[string]interface{}, 0, 1)
 	element := make(map[string]interface{})
 	if input.Conditions != nil {
-		element["conditions"] = flattenOpsGenieNotificationPolicyFilterConditions(input.Conditions)
+		element["conditions"] = flattenOpsgenieConditions(input.Conditions, true)
 	}
 	element["type"] = input.ConditionMatchType
 	output = append(output, element)
 
 	return output
 }
-
-func flattenOpsGenieNotificationPolicyFilterConditions(input []og.Condition) []map[string]interface{} {
-	output := make([]map[string]interface{}, 0, len(input))
-	for _, v := range input {
-		element := make(map[string]interface{})
-		element["field"] = v.Field
-		element["operation"] = v.Operation
-		element["key"] = v.Key
-		element["not"] = v.IsNot
-		element["expected_value"] = v.ExpectedValue
-		element["order"] = v.Order
-		output = append(output, element)
-	}
-
-	return output
-}
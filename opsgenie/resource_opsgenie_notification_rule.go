@@ -6,6 +6,7 @@ import (
 	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
@@ -15,18 +16,34 @@ import (
 
 func resourceOpsGenieNotificationRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsGenieNotificationRuleCreate,
-		Read:   resourceOpsGenieNotificationRuleRead,
-		Update: resourceOpsGenieNotificationRuleUpdate,
-		Delete: resourceOpsGenieNotificationRuleDelete,
+		CreateContext: resourceOpsGenieNotificationRuleCreate,
+		ReadContext:   resourceOpsGenieNotificationRuleRead,
+		UpdateContext: resourceOpsGenieNotificationRuleUpdate,
+		DeleteContext: resourceOpsGenieNotificationRuleDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourceOpsGenieNotificationRuleCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idParts := strings.Split(d.Id(), "/")
 				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 					return nil, fmt.Errorf("Unexpected format of ID (%q), expected username/notification_rule_id", d.Id())
 				}
 				d.Set("username", idParts[0])
 				d.SetId(idParts[1])
+
+				// all the steps of an imported rule are managed
+				client, err := meta.(*OpsgenieClient).notificationClient()
+				if err != nil {
+					return nil, err
+				}
+				result, err := client.ListRuleStep(ctx, &notification.ListRuleStepsRequest{
+					UserIdentifier: idParts[0],
+					RuleId:         idParts[1],
+				})
+				if err != nil {
+					return nil, err
+				}
+				d.Set("step_ids", notificationRuleStepIds(result.RuleSteps))
 				return []*schema.ResourceData{d}, nil
 			},
 		},
@@ -95,6 +112,11 @@ func resourceOpsGenieNotificationRule() *schema.Resource {
 					},
 				},
 			},
+			"step_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"enabled": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -145,42 +167,11 @@ func resourceOpsGenieNotificationRule() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"type": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"conditions": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"field": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-									"key": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									"not": {
-										Type:     schema.TypeBool,
-										Optional: true,
-										Default:  false,
-									},
-									"operation": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-									"expected_value": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									"order": {
-										Type:     schema.TypeInt,
-										Optional: true,
-									},
-								},
-							},
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(conditionMatchTypes, false),
 						},
+						"conditions": conditionsSchema(schema.TypeList, criteriaConditionFields),
 					},
 				},
 			},
@@ -188,10 +179,10 @@ func resourceOpsGenieNotificationRule() *schema.Resource {
 	}
 }
 
-func resourceOpsGenieNotificationRuleCreate(d *schema.ResourceData, meta interface{}) error {
-	client, err := notification.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieNotificationRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).notificationClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	enabled := d.Get("enabled").(bool)
@@ -227,27 +218,49 @@ func resourceOpsGenieNotificationRuleCreate(d *schema.ResourceData, meta interfa
 	}
 
 	log.Printf("[INFO] Creating Notification Rule '%s' for User: '%s'", d.Get("name").(string), d.Get("username").(string))
-	result, err := client.CreateRule(context.Background(), createRequest)
+	result, err := client.CreateRule(ctx, createRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(result.SimpleNotificationRule.Id)
 
-	return resourceOpsGenieNotificationRuleRead(d, meta)
+	// the steps of a new rule are all the ones it was created with
+	steps, err := client.ListRuleStep(ctx, &notification.ListRuleStepsRequest{
+		UserIdentifier: d.Get("username").(string),
+		RuleId:         d.Id(),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("step_ids", notificationRuleStepIds(steps.RuleSteps))
+
+	return resourceOpsGenieNotificationRuleRead(ctx, d, meta)
 }
 
-func resourceOpsGenieNotificationRuleRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := notification.NewClient(meta.(*OpsgenieClient).client.Config)
-	if err != nil {
+// resourceOpsGenieNotificationRuleCustomizeDiff validates the criteria and
+// plans new step ids whenever the steps change.
+func resourceOpsGenieNotificationRuleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateConditionsConfig(d.GetRawConfig(), conditionsBlock{path: []string{"criteria"}, matchType: "type"}); err != nil {
 		return err
 	}
+	if d.Id() != "" && d.HasChange("steps") {
+		return d.SetNewComputed("step_ids")
+	}
+	return nil
+}
+
+func resourceOpsGenieNotificationRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).notificationClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	name := d.Get("name").(string)
 	username := d.Get("username").(string)
 
 	log.Printf("[INFO] Reading OpsGenie Notification Rule '%s' for user '%s'", name, username)
 
-	rule, err := client.GetRule(context.Background(), &notification.GetRuleRequest{
+	rule, err := client.GetRule(ctx, &notification.GetRuleRequest{
 		UserIdentifier: username,
 		RuleId:         d.Id(),
 	})
@@ -269,11 +282,14 @@ func resourceOpsGenieNotificationRuleRead(d *schema.ResourceData, meta interface
 		d.Set("time_restriction", nil)
 	}
 
-	if rule.Steps != nil {
-		d.Set("steps", flattenOpsGenieNotificationRuleSteps(rule.Steps))
-	} else {
-		d.Set("steps", nil)
+	// steps added elsewhere, e.g. in the mobile app or by
+	// opsgenie_notification_rule_step, are ignored
+	steps, err := listManagedOpsGenieNotificationRuleSteps(ctx, client, d, d.Get("step_ids").(*schema.Set), d.Get("steps").(*schema.Set))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	d.Set("steps", flattenOpsGenieNotificationRuleSteps(steps))
+	d.Set("step_ids", notificationRuleStepIds(steps))
 
 	d.Set("name", rule.Name)
 	d.Set("action_type", rule.ActionType)
@@ -284,10 +300,10 @@ func resourceOpsGenieNotificationRuleRead(d *schema.ResourceData, meta interface
 	return nil
 }
 
-func resourceOpsGenieNotificationRuleUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := notification.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieNotificationRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).notificationClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	enabled := d.Get("enabled").(bool)
@@ -313,39 +329,150 @@ func resourceOpsGenieNotificationRuleUpdate(d *schema.ResourceData, meta interfa
 		updateRequest.Schedules = expandOpsGenieNotificationRuleSchedules(d.Get("schedules").([]interface{}))
 	}
 
-	if d.Get("steps").(*schema.Set).Len() > 0 {
-		updateRequest.Steps = expandOpsGenieNotificationRuleSteps(d.Get("steps").(*schema.Set))
-	}
-
 	if len(timeRestriction) > 0 {
 		updateRequest.TimeRestriction = expandOpsGenieTimeRestriction(timeRestriction)
 	}
 
 	log.Printf("[INFO] Updating Notification Rule '%s' for User: '%s'", d.Get("name").(string), d.Get("username").(string))
-	result, err := client.UpdateRule(context.Background(), updateRequest)
+	result, err := client.UpdateRule(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(result.SimpleNotificationRule.Id)
 
-	return resourceOpsGenieNotificationRuleRead(d, meta)
+	if d.HasChange("steps") {
+		if err := updateOpsGenieNotificationRuleSteps(ctx, client, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceOpsGenieNotificationRuleRead(ctx, d, meta)
 }
 
-func resourceOpsGenieNotificationRuleDelete(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("[INFO] Deleting OpsGenie Notification Rule '%s' for user '%s'", d.Get("name").(string), d.Get("username").(string))
-	client, err := notification.NewClient(meta.(*OpsgenieClient).client.Config)
+// updateOpsGenieNotificationRuleSteps changes the steps of a rule one by one,
+// so the steps the resource doesn't manage are kept.
+func updateOpsGenieNotificationRuleSteps(ctx context.Context, client *notification.Client, d *schema.ResourceData) error {
+	username := d.Get("username").(string)
+	oldIds, _ := d.GetChange("step_ids")
+	o, n := d.GetChange("steps")
+
+	existing, err := listManagedOpsGenieNotificationRuleSteps(ctx, client, d, oldIds.(*schema.Set), o.(*schema.Set))
 	if err != nil {
 		return err
 	}
+
+	ids := make([]string, 0)
+	steps := expandOpsGenieNotificationRuleSteps(n.(*schema.Set))
+	for _, step := range existing {
+		unchanged := false
+		for i, s := range steps {
+			if s.Contact == step.Contact && notificationRuleStepSendAfter(s.SendAfter) == step.SendAfter.TimeAmount && *s.Enabled == step.Enabled {
+				steps = append(steps[:i], steps[i+1:]...)
+				unchanged = true
+				break
+			}
+		}
+		if unchanged {
+			ids = append(ids, step.Id)
+			continue
+		}
+
+		log.Printf("[INFO] Deleting step '%s' of Notification Rule '%s'", step.Id, d.Id())
+		_, err := client.DeleteRuleStep(ctx, &notification.DeleteRuleStepRequest{
+			UserIdentifier: username,
+			RuleId:         d.Id(),
+			RuleStepId:     step.Id,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, step := range steps {
+		log.Printf("[INFO] Adding %s step to Notification Rule '%s'", step.Contact.MethodOfContact, d.Id())
+		result, err := client.CreateRuleStep(ctx, &notification.CreateRuleStepRequest{
+			UserIdentifier: username,
+			RuleId:         d.Id(),
+			Contact:        step.Contact,
+			SendAfter:      step.SendAfter,
+			Enabled:        step.Enabled,
+		})
+		if err != nil {
+			d.Set("step_ids", ids)
+			return err
+		}
+		ids = append(ids, result.Id)
+	}
+	d.Set("step_ids", ids)
+	return nil
+}
+
+// listManagedOpsGenieNotificationRuleSteps lists the steps of the rule with
+// the given ids, the ones the resource created or imported. States saved
+// before the ids were tracked have none, the steps with the contacts of the
+// given steps are adopted instead.
+func listManagedOpsGenieNotificationRuleSteps(ctx context.Context, client *notification.Client, d *schema.ResourceData, ids, steps *schema.Set) ([]notification.RuleStep, error) {
+	result, err := client.ListRuleStep(ctx, &notification.ListRuleStepsRequest{
+		UserIdentifier: d.Get("username").(string),
+		RuleId:         d.Id(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := make(map[string]bool)
+	if ids.Len() == 0 {
+		for _, step := range expandOpsGenieNotificationRuleSteps(steps) {
+			contacts[notificationRuleStepContactKey(step.Contact)] = true
+		}
+	}
+	managed := make([]notification.RuleStep, 0, len(result.RuleSteps))
+	for _, step := range result.RuleSteps {
+		if ids.Contains(step.Id) || contacts[notificationRuleStepContactKey(step.Contact)] {
+			managed = append(managed, step)
+		}
+	}
+	return managed, nil
+}
+
+// notificationRuleStepContactKey identifies the steps of a rule by their
+// contact.
+func notificationRuleStepContactKey(contact og.Contact) string {
+	return string(contact.MethodOfContact) + "/" + contact.To
+}
+
+func notificationRuleStepIds(steps []notification.RuleStep) []string {
+	ids := make([]string, 0, len(steps))
+	for _, step := range steps {
+		ids = append(ids, step.Id)
+	}
+	return ids
+}
+
+// notificationRuleStepSendAfter returns the minutes a step is sent after, a
+// step without a send after being sent right away.
+func notificationRuleStepSendAfter(sendAfter *og.SendAfter) uint32 {
+	if sendAfter == nil {
+		return 0
+	}
+	return sendAfter.TimeAmount
+}
+
+func resourceOpsGenieNotificationRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Deleting OpsGenie Notification Rule '%s' for user '%s'", d.Get("name").(string), d.Get("username").(string))
+	client, err := meta.(*OpsgenieClient).notificationClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	deleteRequest := &notification.DeleteRuleRequest{
 		UserIdentifier: d.Get("username").(string),
 		RuleId:         d.Id(),
 	}
 
-	_, err = client.DeleteRule(context.Background(), deleteRequest)
+	_, err = client.DeleteRule(ctx, deleteRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -399,15 +526,13 @@ func expandOpsGenieNotificationRuleStepsContact(input []interface{}) og.Contact
 	return output
 }
 
-func flattenOpsGenieNotificationRuleSteps(input []*notification.StepResult) []map[string]interface{} {
+func flattenOpsGenieNotificationRuleSteps(input []notification.RuleStep) []map[string]interface{} {
 	output := make([]map[string]interface{}, 0, len(input))
 	for _, v := range input {
 		element := make(map[string]interface{})
 		element["enabled"] = v.Enabled
 		element["contact"] = flattenOpsGenieNotificationRuleStepsContact(v.Contact)
-		if v.SendAfter != nil {
-			element["send_after"] = v.SendAfter.TimeAmount
-		}
+		element["send_after"] = v.SendAfter.TimeAmount
 		output = append(output, element)
 	}
 	return output
@@ -453,35 +578,13 @@ func expandOpsgenieNotificationRuleCriteria(input []interface{}) *og.Criteria {
 	for _, r := range input {
 		inputMap := r.(map[string]interface{})
 		criteriaType := inputMap["type"].(string)
-		conditions := expandOpsgenieNotificationRuleCriteriaConditions(inputMap["conditions"].([]interface{}))
+		conditions := expandOpsgenieConditions(inputMap["conditions"].([]interface{}))
 		criteria.Conditions = conditions
 		criteria.CriteriaType = og.ConditionMatchType(criteriaType)
 	}
 	return &criteria
 }
 
-func expandOpsgenieNotificationRuleCriteriaConditions(input []interface{}) []og.Condition {
-	conditions := make([]og.Condition, 0, len(input))
-	if input != nil {
-		for _, v := range input {
-			inputMap := v.(map[string]interface{})
-			condition := og.Condition{}
-			condition.Field = og.ConditionFieldType(inputMap["field"].(string))
-			isNot := inputMap["not"].(bool)
-			condition.IsNot = &isNot
-			condition.Operation = og.ConditionOperation(inputMap["operation"].(string))
-			condition.ExpectedValue = inputMap["expected_value"].(string)
-			key := inputMap["key"].(string)
-			if key != "" {
-				condition.Key = inputMap["key"].(string)
-			}
-			order := inputMap["order"].(int)
-			condition.Order = &order
-			conditions = append(conditions, condition)
-		}
-	}
-	return conditions
-}
 func flattenNotificationSchedules(schedArr []*notification.Schedule) []map[string]interface{} {
 	var schedMap []map[string]interface{}
 	for _, sched := range schedArr {
@@ -0,0 +1,270 @@
+package opsgenie
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/notification"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
+)
+
+// resourceOpsGenieNotificationRuleCopy copies the notification rules of a
+// template user to other users. Targets whose rules no longer match the ones
+// of the template are copied to again.
+func resourceOpsGenieNotificationRuleCopy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOpsGenieNotificationRuleCopyCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsGenieNotificationRuleCopyRead),
+		UpdateContext: resourceOpsGenieNotificationRuleCopyUpdate,
+		DeleteContext: resourceOpsGenieNotificationRuleCopyDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourceOpsGenieNotificationRuleCopyCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"username": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"to_usernames": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"rule_types": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						"all", "new-alert", "acknowledged-alert", "renotified-alert", "closed-alert",
+						"schedule-start", "assigned-alert", "add-note",
+					}, false),
+				},
+			},
+			"out_of_sync_usernames": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// resourceOpsGenieNotificationRuleCopyCustomizeDiff marks the targets out of
+// sync as changing along with the targets and rule types copied.
+func resourceOpsGenieNotificationRuleCopyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && (d.HasChange("to_usernames") || d.HasChange("rule_types")) {
+		return d.SetNewComputed("out_of_sync_usernames")
+	}
+	return nil
+}
+
+// notificationRuleCopiedActionTypes lists the action types of the rules each
+// rule type copies. Rules of the schedule-end and incoming-call-routing action
+// types are never copied, and renotified alert rules have no action type to be
+// told apart by.
+var notificationRuleCopiedActionTypes = map[notification.RuleTypes][]notification.ActionType{
+	notification.All: {
+		notification.CreateAlert, notification.AcknowledgedAlert, notification.ClosedAlert,
+		notification.AssignedAlert, notification.AddNote, notification.ScheduleStart,
+	},
+	notification.NewAlertRule:          {notification.CreateAlert},
+	notification.AcknowledgedAlertRule: {notification.AcknowledgedAlert},
+	notification.ClosedAlertRule:       {notification.ClosedAlert},
+	notification.AssignedAlertRule:     {notification.AssignedAlert},
+	notification.AddNoteRule:           {notification.AddNote},
+	notification.ScheduleStartRule:     {notification.ScheduleStart},
+	notification.RenotifiedAlertRule:   nil,
+}
+
+// notificationRuleCopied tells whether rules with the given action type are
+// copied along with the given rule types.
+func notificationRuleCopied(ruleTypes map[string]bool, actionType notification.ActionType) bool {
+	for ruleType := range ruleTypes {
+		for _, t := range notificationRuleCopiedActionTypes[notification.RuleTypes(ruleType)] {
+			if t == actionType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// notificationRuleCopyFingerprint is the part of a notification rule which is
+// the same for the template and its copies. Steps are compared by their
+// contact method only, as the copies notify their own users.
+type notificationRuleCopyFingerprint struct {
+	Name             string                              `json:"name"`
+	ActionType       notification.ActionType             `json:"actionType"`
+	Enabled          bool                                `json:"enabled"`
+	NotificationTime []notification.NotificationTimeType `json:"notificationTime"`
+	TimeRestriction  *og.TimeRestriction                 `json:"timeRestriction"`
+	Schedules        []string                            `json:"schedules"`
+	Steps            []string                            `json:"steps"`
+}
+
+// listOpsGenieNotificationRuleFingerprints returns the sorted fingerprints of
+// the rules of the user which are copied along with the given rule types.
+func listOpsGenieNotificationRuleFingerprints(ctx context.Context, client *notification.Client, username string, ruleTypes map[string]bool) ([]string, error) {
+	rules, err := client.ListRule(ctx, &notification.ListRuleRequest{
+		UserIdentifier: username,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprints := make([]string, 0, len(rules.SimpleNotificationRules))
+	for _, r := range rules.SimpleNotificationRules {
+		if !notificationRuleCopied(ruleTypes, r.ActionType) {
+			continue
+		}
+		rule, err := client.GetRule(ctx, &notification.GetRuleRequest{
+			UserIdentifier: username,
+			RuleId:         r.Id,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		fingerprint := notificationRuleCopyFingerprint{
+			Name:             rule.Name,
+			ActionType:       rule.ActionType,
+			Enabled:          rule.Enabled,
+			NotificationTime: rule.NotificationTime,
+			TimeRestriction:  rule.TimeRestriction,
+			Schedules:        make([]string, 0, len(rule.Schedules)),
+			Steps:            make([]string, 0, len(rule.Steps)),
+		}
+		for _, schedule := range rule.Schedules {
+			fingerprint.Schedules = append(fingerprint.Schedules, schedule.Name)
+		}
+		for _, step := range rule.Steps {
+			var sendAfter uint32
+			if step.SendAfter != nil {
+				sendAfter = step.SendAfter.TimeAmount
+			}
+			fingerprint.Steps = append(fingerprint.Steps, fmt.Sprintf("%s/%d/%t", step.Contact.MethodOfContact, sendAfter, step.Enabled))
+		}
+		sort.Strings(fingerprint.Schedules)
+		sort.Strings(fingerprint.Steps)
+
+		data, err := json.Marshal(fingerprint)
+		if err != nil {
+			return nil, err
+		}
+		fingerprints = append(fingerprints, string(data))
+	}
+	sort.Strings(fingerprints)
+	return fingerprints, nil
+}
+
+// copyOpsGenieNotificationRules copies the rules of the template user to the
+// given users.
+func copyOpsGenieNotificationRules(ctx context.Context, d *schema.ResourceData, meta interface{}, toUsers []string) error {
+	if len(toUsers) == 0 {
+		return nil
+	}
+	client, err := meta.(*OpsgenieClient).notificationClient()
+	if err != nil {
+		return err
+	}
+
+	ruleTypes := make([]notification.RuleTypes, 0)
+	for _, ruleType := range d.Get("rule_types").(*schema.Set).List() {
+		ruleTypes = append(ruleTypes, notification.RuleTypes(ruleType.(string)))
+	}
+
+	log.Printf("[INFO] Copying the OpsGenie Notification Rules of user '%s' to %v", d.Get("username").(string), toUsers)
+	_, err = client.CopyRule(ctx, &notification.CopyNotificationRulesRequest{
+		UserIdentifier: d.Get("username").(string),
+		ToUsers:        toUsers,
+		RuleTypes:      ruleTypes,
+	})
+	return err
+}
+
+func resourceOpsGenieNotificationRuleCopyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	toUsers := flattenSet(d.Get("to_usernames").(*schema.Set))
+	if err := copyOpsGenieNotificationRules(ctx, d, meta, toUsers); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("username").(string))
+
+	return diag.FromErr(resourceOpsGenieNotificationRuleCopyRead(ctx, d, meta))
+}
+
+func resourceOpsGenieNotificationRuleCopyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).notificationClient()
+	if err != nil {
+		return err
+	}
+	username := d.Get("username").(string)
+	ruleTypes := make(map[string]bool)
+	for _, ruleType := range d.Get("rule_types").(*schema.Set).List() {
+		ruleTypes[ruleType.(string)] = true
+	}
+
+	log.Printf("[INFO] Reading the OpsGenie Notification Rules copied from user '%s'", username)
+	template, err := listOpsGenieNotificationRuleFingerprints(ctx, client, username, ruleTypes)
+	if err != nil {
+		return err
+	}
+
+	// targets out of sync are left out of to_usernames, so they are copied to
+	// again
+	inSync := make([]string, 0)
+	outOfSync := make([]string, 0)
+	for _, target := range flattenSet(d.Get("to_usernames").(*schema.Set)) {
+		rules, err := listOpsGenieNotificationRuleFingerprints(ctx, client, target, ruleTypes)
+		if err != nil {
+			if apiErr, ok := err.(*ogClient.ApiError); !ok || apiErr.StatusCode != 404 {
+				return err
+			}
+		}
+		if err == nil && reflect.DeepEqual(rules, template) {
+			inSync = append(inSync, target)
+		} else {
+			log.Printf("[WARN] The OpsGenie Notification Rules of user '%s' are out of sync with the ones of user '%s'", target, username)
+			outOfSync = append(outOfSync, target)
+		}
+	}
+
+	d.Set("to_usernames", inSync)
+	d.Set("out_of_sync_usernames", outOfSync)
+	return nil
+}
+
+func resourceOpsGenieNotificationRuleCopyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	toUsers := flattenSet(d.Get("to_usernames").(*schema.Set))
+	if !d.HasChange("rule_types") {
+		o, n := d.GetChange("to_usernames")
+		toUsers = flattenSet(n.(*schema.Set).Difference(o.(*schema.Set)))
+	}
+	if err := copyOpsGenieNotificationRules(ctx, d, meta, toUsers); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(resourceOpsGenieNotificationRuleCopyRead(ctx, d, meta))
+}
+
+// resourceOpsGenieNotificationRuleCopyDelete only forgets the copy, the copied
+// rules are kept.
+func resourceOpsGenieNotificationRuleCopyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Removing the copy of the OpsGenie Notification Rules of user '%s' from the state, the copied rules are kept", d.Id())
+	return nil
+}
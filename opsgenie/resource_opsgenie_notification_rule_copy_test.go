@@ -0,0 +1,192 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/notification"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
+)
+
+func TestAccOpsGenieNotificationRuleCopy_basic(t *testing.T) {
+	randomName := acctest.RandString(6)
+	template := fmt.Sprintf("genieuser-%s-template@opsgenie.com", randomName)
+	target := fmt.Sprintf("genieuser-%s-a@opsgenie.com", randomName)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpsGenieNotificationRuleCopy_basic(randomName, "opsgenie_user.a.username"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("opsgenie_notification_rule_copy.test", "to_usernames.#", "1"),
+					resource.TestCheckResourceAttr("opsgenie_notification_rule_copy.test", "out_of_sync_usernames.#", "0"),
+					testCheckOpsGenieNotificationRulesCopied(template, target, 1),
+				),
+			},
+			{
+				Config: testAccOpsGenieNotificationRuleCopy_basic(randomName, "opsgenie_user.a.username, opsgenie_user.b.username"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("opsgenie_notification_rule_copy.test", "to_usernames.#", "2"),
+					testCheckOpsGenieNotificationRulesCopied(template, fmt.Sprintf("genieuser-%s-b@opsgenie.com", randomName), 1),
+				),
+			},
+			{
+				// targets whose rules are changed are copied to again
+				PreConfig: func() {
+					client, _ := notification.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+					rules, err := client.ListRule(context.Background(), &notification.ListRuleRequest{UserIdentifier: target})
+					if err != nil {
+						t.Fatal(err)
+					}
+					for _, rule := range rules.SimpleNotificationRules {
+						client.DeleteRule(context.Background(), &notification.DeleteRuleRequest{UserIdentifier: target, RuleId: rule.Id})
+					}
+				},
+				Config: testAccOpsGenieNotificationRuleCopy_basic(randomName, "opsgenie_user.a.username, opsgenie_user.b.username"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("opsgenie_notification_rule_copy.test", "out_of_sync_usernames.#", "0"),
+					testCheckOpsGenieNotificationRulesCopied(template, target, 1),
+				),
+			},
+			{
+				// so are all targets when the template changes
+				PreConfig: func() {
+					client, _ := notification.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+					enabled := true
+					_, err := client.CreateRule(context.Background(), &notification.CreateRuleRequest{
+						UserIdentifier: template,
+						Name:           "genierule-" + randomName + "-added",
+						ActionType:     notification.CreateAlert,
+						Enabled:        &enabled,
+						Steps: []*og.Step{{
+							Contact:   og.Contact{MethodOfContact: og.Email, To: template},
+							SendAfter: &og.SendAfter{TimeUnit: "minute", TimeAmount: 5},
+							Enabled:   &enabled,
+						}},
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccOpsGenieNotificationRuleCopy_basic(randomName, "opsgenie_user.a.username, opsgenie_user.b.username"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("opsgenie_notification_rule_copy.test", "out_of_sync_usernames.#", "0"),
+					testCheckOpsGenieNotificationRulesCopied(template, target, 2),
+					testCheckOpsGenieNotificationRulesCopied(template, fmt.Sprintf("genieuser-%s-b@opsgenie.com", randomName), 2),
+				),
+			},
+		},
+	})
+}
+
+func TestNotificationRuleCopied(t *testing.T) {
+	cases := []struct {
+		ruleTypes  []string
+		actionType notification.ActionType
+		copied     bool
+	}{
+		{[]string{"new-alert"}, notification.CreateAlert, true},
+		{[]string{"new-alert"}, notification.ClosedAlert, false},
+		{[]string{"closed-alert", "add-note"}, notification.AddNote, true},
+		{[]string{"all"}, notification.ScheduleStart, true},
+		{[]string{"all"}, notification.ScheduleEnd, false},
+		{[]string{"all"}, notification.IncomingCallRouting, false},
+		{[]string{"renotified-alert"}, notification.CreateAlert, false},
+	}
+	for _, c := range cases {
+		ruleTypes := make(map[string]bool)
+		for _, ruleType := range c.ruleTypes {
+			ruleTypes[ruleType] = true
+		}
+		if copied := notificationRuleCopied(ruleTypes, c.actionType); copied != c.copied {
+			t.Errorf("rule types %v, action type %s: expected copied %t, got %t", c.ruleTypes, c.actionType, c.copied, copied)
+		}
+	}
+}
+
+// testCheckOpsGenieNotificationRulesCopied checks the target has copies of
+// the given number of new alert rules of the template.
+func testCheckOpsGenieNotificationRulesCopied(template, target string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := notification.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+		if err != nil {
+			return err
+		}
+		ruleTypes := map[string]bool{"new-alert": true}
+		expected, err := listOpsGenieNotificationRuleFingerprints(context.Background(), client, template, ruleTypes)
+		if err != nil {
+			return err
+		}
+		copied, err := listOpsGenieNotificationRuleFingerprints(context.Background(), client, target, ruleTypes)
+		if err != nil {
+			return err
+		}
+		if len(copied) != count || !reflect.DeepEqual(copied, expected) {
+			return fmt.Errorf("Bad: Expected %d rules copied from %s to %s, got %v", count, template, target, copied)
+		}
+
+		all, err := listOpsGenieNotificationRuleFingerprints(context.Background(), client, target, map[string]bool{"all": true})
+		if err != nil {
+			return err
+		}
+		if len(all) != len(copied) {
+			return fmt.Errorf("Bad: Expected only new alert rules to be copied to %s, got %v", target, all)
+		}
+		return nil
+	}
+}
+
+func testAccOpsGenieNotificationRuleCopy_basic(randomName, targets string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_user" "template" {
+  username  = "genieuser-%s-template@opsgenie.com"
+  full_name = "Acceptance Test Template User"
+  role      = "User"
+}
+resource "opsgenie_user" "a" {
+  username  = "genieuser-%s-a@opsgenie.com"
+  full_name = "Acceptance Test User"
+  role      = "User"
+}
+resource "opsgenie_user" "b" {
+  username  = "genieuser-%s-b@opsgenie.com"
+  full_name = "Acceptance Test User"
+  role      = "User"
+}
+resource "opsgenie_notification_rule" "new_alert" {
+  name        = "genierule-%s-new"
+  username    = opsgenie_user.template.username
+  action_type = "create-alert"
+  steps {
+    send_after = 1
+    contact {
+      method = "email"
+      to     = opsgenie_user.template.username
+    }
+  }
+}
+resource "opsgenie_notification_rule" "closed_alert" {
+  name        = "genierule-%s-closed"
+  username    = opsgenie_user.template.username
+  action_type = "closed-alert"
+  steps {
+    contact {
+      method = "email"
+      to     = opsgenie_user.template.username
+    }
+  }
+}
+resource "opsgenie_notification_rule_copy" "test" {
+  username     = opsgenie_user.template.username
+  to_usernames = [%s]
+  rule_types   = ["new-alert"]
+  depends_on   = [opsgenie_notification_rule.new_alert, opsgenie_notification_rule.closed_alert]
+}
+`, randomName, randomName, randomName, randomName, randomName, targets)
+}
@@ -0,0 +1,205 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/notification"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
+)
+
+// resourceOpsGenieNotificationRuleStep manages one step of a notification
+// rule. The notification rule itself ignores the steps it doesn't list.
+func resourceOpsGenieNotificationRuleStep() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOpsGenieNotificationRuleStepCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsGenieNotificationRuleStepRead),
+		UpdateContext: resourceOpsGenieNotificationRuleStepUpdate,
+		DeleteContext: resourceOpsGenieNotificationRuleStepDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idParts := strings.Split(d.Id(), "/")
+				if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
+					return nil, fmt.Errorf("Unexpected format of ID (%q), expected username/notification_rule_id/step_id", d.Id())
+				}
+				d.Set("username", idParts[0])
+				d.Set("rule_id", idParts[1])
+				d.SetId(idParts[2])
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"username": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"rule_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"contact": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"method": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"email", "sms", "voice", "mobile"}, false),
+						},
+						"to": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"send_after": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func expandOpsGenieNotificationRuleStepSendAfter(d *schema.ResourceData) *og.SendAfter {
+	return &og.SendAfter{
+		TimeUnit:   "minute",
+		TimeAmount: uint32(d.Get("send_after").(int)),
+	}
+}
+
+func resourceOpsGenieNotificationRuleStepCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).notificationClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	username := d.Get("username").(string)
+	ruleId := d.Get("rule_id").(string)
+	enabled := d.Get("enabled").(bool)
+
+	log.Printf("[INFO] Creating OpsGenie Notification Rule Step for rule '%s' of user '%s'", ruleId, username)
+	result, err := client.CreateRuleStep(ctx, &notification.CreateRuleStepRequest{
+		UserIdentifier: username,
+		RuleId:         ruleId,
+		Contact:        expandOpsGenieNotificationRuleStepsContact(d.Get("contact").([]interface{})),
+		SendAfter:      expandOpsGenieNotificationRuleStepSendAfter(d),
+		Enabled:        &enabled,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(result.Id)
+
+	return diag.FromErr(resourceOpsGenieNotificationRuleStepRead(ctx, d, meta))
+}
+
+func resourceOpsGenieNotificationRuleStepRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).notificationClient()
+	if err != nil {
+		return err
+	}
+	username := d.Get("username").(string)
+	ruleId := d.Get("rule_id").(string)
+
+	log.Printf("[INFO] Reading OpsGenie Notification Rule Step '%s' of rule '%s'", d.Id(), ruleId)
+	result, err := client.GetRuleStep(ctx, &notification.GetRuleStepRequest{
+		UserIdentifier: username,
+		RuleId:         ruleId,
+		RuleStepId:     d.Id(),
+	})
+	if err != nil {
+		return err
+	}
+
+	d.Set("contact", flattenOpsGenieNotificationRuleStepsContact(result.RuleStep.Contact))
+	d.Set("send_after", result.RuleStep.SendAfter.TimeAmount)
+	d.Set("enabled", result.RuleStep.Enabled)
+
+	return nil
+}
+
+func resourceOpsGenieNotificationRuleStepUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).notificationClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	username := d.Get("username").(string)
+	ruleId := d.Get("rule_id").(string)
+
+	log.Printf("[INFO] Updating OpsGenie Notification Rule Step '%s' of rule '%s'", d.Id(), ruleId)
+	if d.HasChange("contact") || d.HasChange("send_after") {
+		contact := expandOpsGenieNotificationRuleStepsContact(d.Get("contact").([]interface{}))
+		_, err := client.UpdateRuleStep(ctx, &notification.UpdateRuleStepRequest{
+			UserIdentifier: username,
+			RuleId:         ruleId,
+			RuleStepId:     d.Id(),
+			Contact:        &contact,
+			SendAfter:      expandOpsGenieNotificationRuleStepSendAfter(d),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("enabled") {
+		if d.Get("enabled").(bool) {
+			_, err = client.EnableRuleStep(ctx, &notification.EnableRuleStepRequest{
+				UserIdentifier: username,
+				RuleId:         ruleId,
+				RuleStepId:     d.Id(),
+			})
+		} else {
+			_, err = client.DisableRuleStep(ctx, &notification.DisableRuleStepRequest{
+				UserIdentifier: username,
+				RuleId:         ruleId,
+				RuleStepId:     d.Id(),
+			})
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diag.FromErr(resourceOpsGenieNotificationRuleStepRead(ctx, d, meta))
+}
+
+func resourceOpsGenieNotificationRuleStepDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).notificationClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Deleting OpsGenie Notification Rule Step '%s' of rule '%s'", d.Id(), d.Get("rule_id").(string))
+	_, err = client.DeleteRuleStep(ctx, &notification.DeleteRuleStepRequest{
+		UserIdentifier: d.Get("username").(string),
+		RuleId:         d.Get("rule_id").(string),
+		RuleStepId:     d.Id(),
+	})
+	if err != nil {
+		if apiErr, ok := err.(*ogClient.ApiError); ok && apiErr.StatusCode == 404 {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
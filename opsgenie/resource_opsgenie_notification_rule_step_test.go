@@ -0,0 +1,194 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/notification"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
+)
+
+func TestAccOpsGenieNotificationRuleStep_basic(t *testing.T) {
+	randomName := acctest.RandString(6)
+	var ruleId string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckOpsGenieNotificationRuleStepDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpsGenieNotificationRuleStep_basic(randomName, 0, 5, true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieNotificationRuleStepExists("opsgenie_notification_rule_step.test"),
+					resource.TestCheckResourceAttr("opsgenie_notification_rule_step.test", "send_after", "5"),
+					resource.TestCheckResourceAttr("opsgenie_notification_rule.test", "steps.#", "1"),
+					testCheckOpsGenieNotificationRuleStepCount("opsgenie_notification_rule.test", 2),
+					func(s *terraform.State) error {
+						ruleId = s.RootModule().Resources["opsgenie_notification_rule.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccOpsGenieNotificationRuleStep_basic(randomName, 0, 10, false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieNotificationRuleStepExists("opsgenie_notification_rule_step.test"),
+					resource.TestCheckResourceAttr("opsgenie_notification_rule_step.test", "send_after", "10"),
+					resource.TestCheckResourceAttr("opsgenie_notification_rule_step.test", "enabled", "false"),
+					testCheckOpsGenieNotificationRuleStepCount("opsgenie_notification_rule.test", 2),
+				),
+			},
+			{
+				// steps added elsewhere are kept when the rule changes its own,
+				// even with the contact of one of its steps
+				PreConfig: func() {
+					client, _ := notification.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+					username := fmt.Sprintf("genieuser-%s@opsgenie.com", randomName)
+					enabled := true
+					_, err := client.CreateRuleStep(context.Background(), &notification.CreateRuleStepRequest{
+						UserIdentifier: username,
+						RuleId:         ruleId,
+						Contact:        og.Contact{MethodOfContact: og.Email, To: username},
+						SendAfter:      &og.SendAfter{TimeUnit: "minute", TimeAmount: 15},
+						Enabled:        &enabled,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccOpsGenieNotificationRuleStep_basic(randomName, 1, 10, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("opsgenie_notification_rule.test", "steps.#", "1"),
+					resource.TestCheckResourceAttr("opsgenie_notification_rule.test", "steps.0.send_after", "1"),
+					resource.TestCheckResourceAttr("opsgenie_notification_rule.test", "step_ids.#", "1"),
+					testCheckOpsGenieNotificationRuleStepCount("opsgenie_notification_rule.test", 3),
+				),
+			},
+			{
+				ResourceName:      "opsgenie_notification_rule_step.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["opsgenie_notification_rule_step.test"]
+					return fmt.Sprintf("%s/%s/%s", rs.Primary.Attributes["username"], rs.Primary.Attributes["rule_id"], rs.Primary.ID), nil
+				},
+			},
+		},
+	})
+}
+
+func testCheckOpsGenieNotificationRuleStepDestroy(s *terraform.State) error {
+	client, err := notification.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "opsgenie_notification_rule_step" {
+			continue
+		}
+		_, err := client.GetRuleStep(context.Background(), &notification.GetRuleStepRequest{
+			UserIdentifier: rs.Primary.Attributes["username"],
+			RuleId:         rs.Primary.Attributes["rule_id"],
+			RuleStepId:     rs.Primary.ID,
+		})
+		if err == nil {
+			return fmt.Errorf("Notification rule step %s still exists", rs.Primary.ID)
+		}
+		if apiErr, ok := err.(*ogClient.ApiError); !ok || apiErr.StatusCode != 404 {
+			return err
+		}
+	}
+	return nil
+}
+
+func testCheckOpsGenieNotificationRuleStepExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		client, err := notification.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+		if err != nil {
+			return err
+		}
+		_, err = client.GetRuleStep(context.Background(), &notification.GetRuleStepRequest{
+			UserIdentifier: rs.Primary.Attributes["username"],
+			RuleId:         rs.Primary.Attributes["rule_id"],
+			RuleStepId:     rs.Primary.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("Bad: Notification rule step %q does not exist", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+// testCheckOpsGenieNotificationRuleStepCount checks the number of steps a rule
+// has, including the ones it doesn't manage.
+func testCheckOpsGenieNotificationRuleStepCount(name string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		client, err := notification.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+		if err != nil {
+			return err
+		}
+		result, err := client.ListRuleStep(context.Background(), &notification.ListRuleStepsRequest{
+			UserIdentifier: rs.Primary.Attributes["username"],
+			RuleId:         rs.Primary.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(result.RuleSteps) != count {
+			return fmt.Errorf("Bad: Expected %d steps, got %d", count, len(result.RuleSteps))
+		}
+		return nil
+	}
+}
+
+func testAccOpsGenieNotificationRuleStep_basic(randomName string, ruleSendAfter, sendAfter int, enabled bool) string {
+	return fmt.Sprintf(`
+resource "opsgenie_user" "test" {
+  username  = "genieuser-%s@opsgenie.com"
+  full_name = "Acceptance Test User"
+  role      = "User"
+}
+resource "opsgenie_user_contact" "sms" {
+  username = opsgenie_user.test.username
+  to       = "90-123"
+  method   = "sms"
+}
+resource "opsgenie_notification_rule" "test" {
+  name        = "genierule-%s"
+  username    = opsgenie_user.test.username
+  action_type = "create-alert"
+  steps {
+    send_after = %d
+    contact {
+      method = "email"
+      to     = opsgenie_user.test.username
+    }
+  }
+}
+resource "opsgenie_notification_rule_step" "test" {
+  username   = opsgenie_user.test.username
+  rule_id    = opsgenie_notification_rule.test.id
+  send_after = %d
+  enabled    = %t
+  contact {
+    method = opsgenie_user_contact.sms.method
+    to     = opsgenie_user_contact.sms.to
+  }
+}
+`, randomName, randomName, ruleSendAfter, sendAfter, enabled)
+}
@@ -0,0 +1,206 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/policy"
+)
+
+// globalPolicyOrderId is the ID of the order of the global alert policies.
+const globalPolicyOrderId = "global"
+
+func resourceOpsGenieAlertPolicyOrder() *schema.Resource {
+	return resourceOpsGeniePolicyOrder(policy.AlertPolicy, &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+	})
+}
+
+func resourceOpsGenieNotificationPolicyOrder() *schema.Resource {
+	return resourceOpsGeniePolicyOrder(policy.NotificationPolicy, &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	})
+}
+
+// resourceOpsGeniePolicyOrder manages the order of the policies of the given
+// type of a team, or of the global alert policies. The listed policies are
+// moved to the top, in the given order, and the other policies follow them.
+func resourceOpsGeniePolicyOrder(policyType policy.PolicyType, teamIdSchema *schema.Schema) *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOpsGeniePolicyOrderUpdate(policyType),
+		ReadContext:   handleNonExistentResource(resourceOpsGeniePolicyOrderRead(policyType)),
+		UpdateContext: resourceOpsGeniePolicyOrderUpdate(policyType),
+		DeleteContext: resourceOpsGenieOrderDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"team_id": teamIdSchema,
+			"policy_ids": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// changePolicyOrderRequest is a policy.ChangeOrderRequest sending a target
+// index of 0 as well, which the SDK leaves out.
+type changePolicyOrderRequest struct {
+	policy.ChangeOrderRequest
+	TargetIndex int `json:"targetIndex"`
+}
+
+func listOpsGeniePolicyIds(ctx context.Context, client *policy.Client, policyType policy.PolicyType, teamId string) ([]string, error) {
+	var result *policy.ListPolicyResult
+	var err error
+	if policyType == policy.AlertPolicy {
+		result, err = client.ListAlertPolicies(ctx, &policy.ListAlertPoliciesRequest{TeamId: teamId})
+	} else {
+		result, err = client.ListNotificationPolicies(ctx, &policy.ListNotificationPoliciesRequest{TeamId: teamId})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(result.Policies, func(i, j int) bool {
+		return result.Policies[i].Order < result.Policies[j].Order
+	})
+	ids := make([]string, 0, len(result.Policies))
+	for _, p := range result.Policies {
+		ids = append(ids, p.Id)
+	}
+	return ids, nil
+}
+
+func resourceOpsGeniePolicyOrderUpdate(policyType policy.PolicyType) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client, err := meta.(*OpsgenieClient).policyClient()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		teamId := d.Get("team_id").(string)
+
+		current, err := listOpsGeniePolicyIds(ctx, client, policyType, teamId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		log.Printf("[INFO] Ordering OpsGenie %s policies of team '%s'", policyType, teamId)
+		err = reorderOpsGenieIds(current, expandOpsGenieOrderIds(d.Get("policy_ids")), func(id string, index int) error {
+			return meta.(*OpsgenieClient).client.Exec(ctx, &changePolicyOrderRequest{
+				ChangeOrderRequest: policy.ChangeOrderRequest{
+					Id:          id,
+					TeamId:      teamId,
+					Type:        policyType,
+					TargetIndex: index,
+				},
+				TargetIndex: index,
+			}, &policy.PolicyResult{})
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if teamId == "" {
+			d.SetId(globalPolicyOrderId)
+		} else {
+			d.SetId(teamId)
+		}
+
+		return diag.FromErr(resourceOpsGeniePolicyOrderRead(policyType)(ctx, d, meta))
+	}
+}
+
+func resourceOpsGeniePolicyOrderRead(policyType policy.PolicyType) func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+		client, err := meta.(*OpsgenieClient).policyClient()
+		if err != nil {
+			return err
+		}
+		teamId := ""
+		if d.Id() != globalPolicyOrderId {
+			teamId = d.Id()
+		}
+
+		log.Printf("[INFO] Reading OpsGenie %s policy order of team '%s'", policyType, teamId)
+		current, err := listOpsGeniePolicyIds(ctx, client, policyType, teamId)
+		if err != nil {
+			return err
+		}
+
+		d.Set("team_id", teamId)
+		d.Set("policy_ids", orderedIdsPrefix(current, d.Get("policy_ids")))
+		return nil
+	}
+}
+
+// resourceOpsGenieOrderDelete only forgets an order, the entities stay in the
+// order they are in.
+func resourceOpsGenieOrderDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Removing the order '%s' from the state, the order itself is kept", d.Id())
+	return nil
+}
+
+// expandOpsGenieOrderIds returns the ids of an ordered list.
+func expandOpsGenieOrderIds(input interface{}) []string {
+	ids := make([]string, 0)
+	for _, v := range input.([]interface{}) {
+		ids = append(ids, v.(string))
+	}
+	return ids
+}
+
+// orderedIdsPrefix returns as many of the current ids as are ordered, so
+// ids moved in front of or between them show up as a difference. All of them
+// are returned if none are ordered yet, e.g. when importing.
+func orderedIdsPrefix(current []string, ordered interface{}) []string {
+	n := len(expandOpsGenieOrderIds(ordered))
+	if n == 0 || n > len(current) {
+		return current
+	}
+	return current[:n]
+}
+
+// reorderOpsGenieIds moves the given ids to the top of the current order, in
+// the given order, calling move for every id which isn't at its index yet.
+func reorderOpsGenieIds(current, ids []string, move func(id string, index int) error) error {
+	order := append([]string{}, current...)
+	for i, id := range ids {
+		j := -1
+		for k, existing := range order {
+			if existing == id {
+				j = k
+				break
+			}
+		}
+		switch {
+		case j < 0:
+			return fmt.Errorf("%s doesn't exist", id)
+		case j < i:
+			return fmt.Errorf("%s is listed more than once", id)
+		case j == i:
+			continue
+		}
+
+		if err := move(id, i); err != nil {
+			return err
+		}
+		order = append(order[:j], order[j+1:]...)
+		order = append(order[:i], append([]string{id}, order[i:]...)...)
+	}
+	return nil
+}
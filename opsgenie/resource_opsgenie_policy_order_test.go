@@ -0,0 +1,279 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/policy"
+)
+
+func TestReorderOpsGenieIds(t *testing.T) {
+	cases := []struct {
+		name     string
+		current  []string
+		ids      []string
+		expected []string
+		err      string
+	}{
+		{
+			name:     "ordered",
+			current:  []string{"a", "b", "c"},
+			ids:      []string{"a", "b"},
+			expected: nil,
+		},
+		{
+			name:     "reversed",
+			current:  []string{"a", "b", "c"},
+			ids:      []string{"c", "b", "a"},
+			expected: []string{"c:0", "b:1"},
+		},
+		{
+			name:     "unlisted in front",
+			current:  []string{"x", "a", "b"},
+			ids:      []string{"a", "b"},
+			expected: []string{"a:0", "b:1"},
+		},
+		{
+			name:    "missing",
+			current: []string{"a", "b"},
+			ids:     []string{"a", "c"},
+			err:     "c doesn't exist",
+		},
+		{
+			name:    "duplicate",
+			current: []string{"a", "b"},
+			ids:     []string{"a", "a"},
+			err:     "a is listed more than once",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var moves []string
+			err := reorderOpsGenieIds(c.current, c.ids, func(id string, index int) error {
+				moves = append(moves, fmt.Sprintf("%s:%d", id, index))
+				return nil
+			})
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Fatalf("Expected error %q, got %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(moves, c.expected) {
+				t.Errorf("Expected moves %v, got %v", c.expected, moves)
+			}
+		})
+	}
+}
+
+func TestAccOpsGenieAlertPolicyOrder_basic(t *testing.T) {
+	randomTeam := acctest.RandString(6)
+	randomPolicy := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpsGenieAlertPolicyOrder_basic(randomTeam, randomPolicy, "c", "a", "b"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGeniePolicyOrder("opsgenie_alert_policy_order.test", policy.AlertPolicy, "c", "a", "b"),
+					resource.TestCheckResourceAttr("opsgenie_alert_policy_order.test", "policy_ids.#", "3"),
+				),
+			},
+			{
+				Config: testAccOpsGenieAlertPolicyOrder_basic(randomTeam, randomPolicy, "b", "a"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGeniePolicyOrder("opsgenie_alert_policy_order.test", policy.AlertPolicy, "b", "a", "c"),
+					resource.TestCheckResourceAttr("opsgenie_alert_policy_order.test", "policy_ids.#", "2"),
+				),
+			},
+			{
+				// policies reordered outside of Terraform are ordered again
+				PreConfig: func() {
+					client, _ := policy.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+					result, err := client.ListAlertPolicies(context.Background(), &policy.ListAlertPoliciesRequest{TeamId: testAccOpsGeniePolicyOrderTeamId})
+					if err != nil {
+						t.Fatal(err)
+					}
+					for _, p := range result.Policies {
+						if strings.HasSuffix(p.Name, "-c") {
+							err := testAccProvider.Meta().(*OpsgenieClient).client.Exec(context.Background(), &changePolicyOrderRequest{
+								ChangeOrderRequest: policy.ChangeOrderRequest{Id: p.Id, TeamId: testAccOpsGeniePolicyOrderTeamId, Type: policy.AlertPolicy},
+							}, &policy.PolicyResult{})
+							if err != nil {
+								t.Fatal(err)
+							}
+						}
+					}
+				},
+				Config: testAccOpsGenieAlertPolicyOrder_basic(randomTeam, randomPolicy, "b", "a"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGeniePolicyOrder("opsgenie_alert_policy_order.test", policy.AlertPolicy, "b", "a", "c"),
+				),
+			},
+			{
+				ResourceName:            "opsgenie_alert_policy_order.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"policy_ids"},
+			},
+		},
+	})
+}
+
+func TestAccOpsGenieNotificationPolicyOrder_basic(t *testing.T) {
+	randomTeam := acctest.RandString(6)
+	randomPolicy := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpsGenieNotificationPolicyOrder_basic(randomTeam, randomPolicy, "b", "a"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGeniePolicyOrder("opsgenie_notification_policy_order.test", policy.NotificationPolicy, "b", "a"),
+				),
+			},
+			{
+				Config: testAccOpsGenieNotificationPolicyOrder_basic(randomTeam, randomPolicy, "a", "b"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGeniePolicyOrder("opsgenie_notification_policy_order.test", policy.NotificationPolicy, "a", "b"),
+				),
+			},
+		},
+	})
+}
+
+// testAccOpsGeniePolicyOrderTeamId is the team of the policies ordered by the
+// last testCheckOpsGeniePolicyOrder.
+var testAccOpsGeniePolicyOrderTeamId string
+
+// testCheckOpsGeniePolicyOrder checks the order of the policies by the
+// suffixes of their names.
+func testCheckOpsGeniePolicyOrder(name string, policyType policy.PolicyType, suffixes ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		teamId := rs.Primary.Attributes["team_id"]
+		testAccOpsGeniePolicyOrderTeamId = teamId
+
+		client, err := policy.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+		if err != nil {
+			return err
+		}
+		ids, err := listOpsGeniePolicyIds(context.Background(), client, policyType, teamId)
+		if err != nil {
+			return err
+		}
+		if len(ids) != len(suffixes) {
+			return fmt.Errorf("Bad: Expected %d policies, got %d", len(suffixes), len(ids))
+		}
+		for i, id := range ids {
+			var policyName string
+			if policyType == policy.AlertPolicy {
+				result, err := client.GetAlertPolicy(context.Background(), &policy.GetAlertPolicyRequest{Id: id, TeamId: teamId})
+				if err != nil {
+					return err
+				}
+				policyName = result.Name
+			} else {
+				result, err := client.GetNotificationPolicy(context.Background(), &policy.GetNotificationPolicyRequest{Id: id, TeamId: teamId})
+				if err != nil {
+					return err
+				}
+				policyName = result.Name
+			}
+			if !strings.HasSuffix(policyName, "-"+suffixes[i]) {
+				return fmt.Errorf("Bad: Expected policy %d to end with %q, got %q", i, suffixes[i], policyName)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccOpsGenieAlertPolicyOrder_basic(randomTeam, randomPolicy string, order ...string) string {
+	ids := make([]string, 0, len(order))
+	for _, o := range order {
+		ids = append(ids, fmt.Sprintf("opsgenie_alert_policy.%s.id", o))
+	}
+	return fmt.Sprintf(`
+resource "opsgenie_team" "test" {
+  name        = "genieteam-%s"
+  description = "This team deals with all the things"
+}
+resource "opsgenie_alert_policy" "a" {
+  name    = "genie-alert-policy-%s-a"
+  team_id = opsgenie_team.test.id
+  message = "{{message}}"
+  filter {}
+}
+resource "opsgenie_alert_policy" "b" {
+  name    = "genie-alert-policy-%s-b"
+  team_id = opsgenie_team.test.id
+  message = "{{message}}"
+  filter {}
+  depends_on = [opsgenie_alert_policy.a]
+}
+resource "opsgenie_alert_policy" "c" {
+  name    = "genie-alert-policy-%s-c"
+  team_id = opsgenie_team.test.id
+  message = "{{message}}"
+  filter {}
+  depends_on = [opsgenie_alert_policy.b]
+}
+resource "opsgenie_alert_policy_order" "test" {
+  team_id    = opsgenie_team.test.id
+  policy_ids = [%s]
+}
+`, randomTeam, randomPolicy, randomPolicy, randomPolicy, strings.Join(ids, ", "))
+}
+
+func testAccOpsGenieNotificationPolicyOrder_basic(randomTeam, randomPolicy string, order ...string) string {
+	ids := make([]string, 0, len(order))
+	for _, o := range order {
+		ids = append(ids, fmt.Sprintf("opsgenie_notification_policy.%s.id", o))
+	}
+	return fmt.Sprintf(`
+resource "opsgenie_team" "test" {
+  name        = "genieteam-%s"
+  description = "This team deals with all the things"
+}
+resource "opsgenie_notification_policy" "a" {
+  name    = "geniepolicy-%s-a"
+  team_id = opsgenie_team.test.id
+  delay_action {
+    delay_option = "next-time"
+    until_minute = 30
+    until_hour   = 7
+  }
+  filter {}
+}
+resource "opsgenie_notification_policy" "b" {
+  name    = "geniepolicy-%s-b"
+  team_id = opsgenie_team.test.id
+  delay_action {
+    delay_option = "next-time"
+    until_minute = 30
+    until_hour   = 7
+  }
+  filter {}
+  depends_on = [opsgenie_notification_policy.a]
+}
+resource "opsgenie_notification_policy_order" "test" {
+  team_id    = opsgenie_team.test.id
+  policy_ids = [%s]
+}
+`, randomTeam, randomPolicy, randomPolicy, strings.Join(ids, ", "))
+}
@@ -11,17 +11,19 @@ import (
 
 	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceOpsgenieSchedule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsgenieScheduleCreate,
-		Read:   handleNonExistentResource(resourceOpsgenieScheduleRead),
-		Update: resourceOpsgenieScheduleUpdate,
-		Delete: resourceOpsgenieScheduleDelete,
+		CreateContext: resourceOpsgenieScheduleCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsgenieScheduleRead),
+		UpdateContext: resourceOpsgenieScheduleUpdate,
+		DeleteContext: resourceOpsgenieScheduleDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importStateByIdOrName("schedule", lookupOpsgenieSchedule(schedule.Id), lookupOpsgenieSchedule(schedule.Name)),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -67,10 +69,10 @@ func checkTimeZoneDifference(k, old, new string, d *schema.ResourceData) bool {
 	return timeOld.Format(time.ANSIC) == timeNew.Format(time.ANSIC)
 }
 
-func resourceOpsgenieScheduleCreate(d *schema.ResourceData, meta interface{}) error {
-	client, err := schedule.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieScheduleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).scheduleClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -92,18 +94,18 @@ func resourceOpsgenieScheduleCreate(d *schema.ResourceData, meta interface{}) er
 
 	log.Printf("[INFO] Creating OpsGenie schedule '%s'", name)
 
-	result, err := client.Create(context.Background(), createRequest)
+	result, err := client.Create(ctx, createRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(result.Id)
 
-	return resourceOpsgenieScheduleRead(d, meta)
+	return diag.FromErr(resourceOpsgenieScheduleRead(ctx, d, meta))
 }
 
-func resourceOpsgenieScheduleRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := schedule.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieScheduleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).scheduleClient()
 	if err != nil {
 		return err
 	}
@@ -113,7 +115,7 @@ func resourceOpsgenieScheduleRead(d *schema.ResourceData, meta interface{}) erro
 		IdentifierValue: d.Id(),
 	}
 
-	getResponse, err := client.Get(context.Background(), getRequest)
+	getResponse, err := client.Get(ctx, getRequest)
 	if err != nil {
 		return err
 	}
@@ -129,10 +131,10 @@ func resourceOpsgenieScheduleRead(d *schema.ResourceData, meta interface{}) erro
 	return nil
 }
 
-func resourceOpsgenieScheduleUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := schedule.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieScheduleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).scheduleClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -158,28 +160,28 @@ func resourceOpsgenieScheduleUpdate(d *schema.ResourceData, meta interface{}) er
 	}
 	log.Printf("[INFO] Updating OpsGenie schedule '%s'", name)
 
-	_, err = client.Update(context.Background(), updateRequest)
+	_, err = client.Update(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceOpsgenieScheduleDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsgenieScheduleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Deleting OpsGenie schedule '%s'", d.Get("name").(string))
-	client, err := schedule.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).scheduleClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	deleteRequest := &schedule.DeleteRequest{
 		IdentifierType:  schedule.Id,
 		IdentifierValue: d.Id(),
 	}
 
-	_, err = client.Delete(context.Background(), deleteRequest)
+	_, err = client.Delete(ctx, deleteRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -207,3 +209,20 @@ func validateOpsgenieScheduleDescription(v interface{}, k string) (ws []string,
 
 	return
 }
+
+func lookupOpsgenieSchedule(identifierType schedule.Identifier) importLookup {
+	return func(ctx context.Context, meta interface{}, identifier string) (string, error) {
+		client, err := meta.(*OpsgenieClient).scheduleClient()
+		if err != nil {
+			return "", err
+		}
+		result, err := client.Get(ctx, &schedule.GetRequest{
+			IdentifierType:  identifierType,
+			IdentifierValue: identifier,
+		})
+		if err != nil {
+			return "", err
+		}
+		return result.Schedule.Id, nil
+	}
+}
@@ -0,0 +1,240 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/schedule"
+)
+
+func resourceOpsgenieScheduleOverride() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOpsgenieScheduleOverrideCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsgenieScheduleOverrideRead),
+		UpdateContext: resourceOpsgenieScheduleOverrideUpdate,
+		DeleteContext: resourceOpsgenieScheduleOverrideDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idParts := strings.Split(d.Id(), "/")
+				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+					return nil, fmt.Errorf("Unexpected format of ID (%q), expected schedule_id/alias", d.Id())
+				}
+				scheduleId := idParts[0]
+				alias := idParts[1]
+				d.Set("schedule_id", scheduleId)
+				d.Set("alias", alias)
+				d.SetId(alias)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"schedule_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"alias": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"start_date": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateDate,
+			},
+			"end_date": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateDate,
+			},
+			"responder": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"user", "team", "escalation"}, false),
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"rotation_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceOpsgenieScheduleOverrideCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).scheduleClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	scheduleId := d.Get("schedule_id").(string)
+	alias := d.Get("alias").(string)
+
+	startDate, err := time.Parse(time.RFC3339, d.Get("start_date").(string))
+	if err != nil {
+		return diag.Errorf("Cannot parse start_date: %s", err)
+	}
+	endDate, err := time.Parse(time.RFC3339, d.Get("end_date").(string))
+	if err != nil {
+		return diag.Errorf("Cannot parse end_date: %s", err)
+	}
+
+	createRequest := &schedule.CreateScheduleOverrideRequest{
+		ScheduleIdentifierType: schedule.Id,
+		ScheduleIdentifier:     scheduleId,
+		Alias:                  alias,
+		User:                   expandOpsgenieScheduleOverrideResponder(d.Get("responder").([]interface{})),
+		StartDate:              startDate,
+		EndDate:                endDate,
+		Rotations:              expandOpsgenieScheduleOverrideRotations(d.Get("rotation_ids").(*schema.Set)),
+	}
+
+	log.Printf("[INFO] Creating OpsGenie schedule override for schedule '%s'", scheduleId)
+
+	result, err := client.CreateScheduleOverride(ctx, createRequest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(result.Alias)
+
+	return diag.FromErr(resourceOpsgenieScheduleOverrideRead(ctx, d, meta))
+}
+
+func resourceOpsgenieScheduleOverrideRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).scheduleClient()
+	if err != nil {
+		return err
+	}
+
+	getRequest := &schedule.GetScheduleOverrideRequest{
+		ScheduleIdentifierType: schedule.Id,
+		ScheduleIdentifier:     d.Get("schedule_id").(string),
+		Alias:                  d.Id(),
+	}
+	getResponse, err := client.GetScheduleOverride(ctx, getRequest)
+	if err != nil {
+		return err
+	}
+
+	d.Set("alias", getResponse.Alias)
+	d.Set("start_date", getResponse.StartDate.UTC().Format("2006-01-02T15:04:05Z"))
+	d.Set("end_date", getResponse.EndDate.UTC().Format("2006-01-02T15:04:05Z"))
+	d.Set("responder", flattenOpsgenieScheduleOverrideResponder(getResponse.User))
+	d.Set("rotation_ids", flattenOpsgenieScheduleOverrideRotations(getResponse.Rotations))
+
+	return nil
+}
+
+func resourceOpsgenieScheduleOverrideUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).scheduleClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	scheduleId := d.Get("schedule_id").(string)
+
+	startDate, err := time.Parse(time.RFC3339, d.Get("start_date").(string))
+	if err != nil {
+		return diag.Errorf("Cannot parse start_date: %s", err)
+	}
+	endDate, err := time.Parse(time.RFC3339, d.Get("end_date").(string))
+	if err != nil {
+		return diag.Errorf("Cannot parse end_date: %s", err)
+	}
+
+	updateRequest := &schedule.UpdateScheduleOverrideRequest{
+		ScheduleIdentifierType: schedule.Id,
+		ScheduleIdentifier:     scheduleId,
+		Alias:                  d.Id(),
+		User:                   expandOpsgenieScheduleOverrideResponder(d.Get("responder").([]interface{})),
+		StartDate:              startDate,
+		EndDate:                endDate,
+		Rotations:              expandOpsgenieScheduleOverrideRotations(d.Get("rotation_ids").(*schema.Set)),
+	}
+
+	log.Printf("[INFO] Updating OpsGenie schedule override '%s'", d.Id())
+
+	_, err = client.UpdateScheduleOverride(ctx, updateRequest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(resourceOpsgenieScheduleOverrideRead(ctx, d, meta))
+}
+
+func resourceOpsgenieScheduleOverrideDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Deleting OpsGenie schedule override '%s'", d.Id())
+	client, err := meta.(*OpsgenieClient).scheduleClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deleteRequest := &schedule.DeleteScheduleOverrideRequest{
+		ScheduleIdentifierType: schedule.Id,
+		ScheduleIdentifier:     d.Get("schedule_id").(string),
+		Alias:                  d.Id(),
+	}
+
+	_, err = client.DeleteScheduleOverride(ctx, deleteRequest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func expandOpsgenieScheduleOverrideResponder(input []interface{}) schedule.Responder {
+	responder := schedule.Responder{}
+	for _, v := range input {
+		config := v.(map[string]interface{})
+		responder.Type = schedule.ResponderType(config["type"].(string))
+		responder.Id = config["id"].(string)
+	}
+	return responder
+}
+
+func flattenOpsgenieScheduleOverrideResponder(input schedule.Responder) []map[string]interface{} {
+	output := make([]map[string]interface{}, 0, 1)
+	element := make(map[string]interface{})
+	element["type"] = string(input.Type)
+	element["id"] = input.Id
+	output = append(output, element)
+	return output
+}
+
+func expandOpsgenieScheduleOverrideRotations(input *schema.Set) []schedule.RotationIdentifier {
+	rotations := make([]schedule.RotationIdentifier, 0, input.Len())
+	for _, v := range input.List() {
+		rotations = append(rotations, schedule.RotationIdentifier{Id: v.(string)})
+	}
+	return rotations
+}
+
+func flattenOpsgenieScheduleOverrideRotations(input []schedule.RotationIdentifier) []string {
+	rotations := make([]string, 0, len(input))
+	for _, r := range input {
+		rotations = append(rotations, r.Id)
+	}
+	return rotations
+}
@@ -0,0 +1,194 @@
+package opsgenie
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/schedule"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccOpsGenieScheduleOverride_basic(t *testing.T) {
+	randomUser := acctest.RandString(6)
+	randomTeam := acctest.RandString(6)
+	randomSchedule := acctest.RandString(6)
+	config := testAccOpsGenieScheduleOverride_basic(randomUser, randomTeam, randomSchedule)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckOpsGenieScheduleOverrideDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieScheduleOverrideExists("opsgenie_schedule_override.test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOpsGenieScheduleOverride_complete(t *testing.T) {
+	randomUser := acctest.RandString(6)
+	randomTeam := acctest.RandString(6)
+	randomSchedule := acctest.RandString(6)
+	randomRotation := acctest.RandString(6)
+	randomAlias := acctest.RandString(6)
+	config := testAccOpsGenieScheduleOverride_complete(randomUser, randomTeam, randomSchedule, randomRotation, randomAlias)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckOpsGenieScheduleOverrideDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieScheduleOverrideExists("opsgenie_schedule_override.test"),
+					resource.TestCheckResourceAttr("opsgenie_schedule_override.test", "alias", "genieoverride-"+randomAlias),
+					resource.TestCheckResourceAttr("opsgenie_schedule_override.test", "rotation_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckOpsGenieScheduleOverrideDestroy(s *terraform.State) error {
+	client, err := schedule.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "opsgenie_schedule_override" {
+			continue
+		}
+
+		req := schedule.GetScheduleOverrideRequest{
+			ScheduleIdentifierType: schedule.Id,
+			ScheduleIdentifier:     rs.Primary.Attributes["schedule_id"],
+			Alias:                  rs.Primary.Attributes["id"],
+		}
+		_, err := client.GetScheduleOverride(context.Background(), &req)
+		if err != nil {
+			x := err.(*ogClient.ApiError)
+			if x.StatusCode != 404 {
+				return errors.New(fmt.Sprintf("Schedule override still exists : %s", x.Error()))
+			}
+		}
+	}
+	return nil
+}
+
+func testCheckOpsGenieScheduleOverrideExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		alias := rs.Primary.Attributes["id"]
+		scheduleId := rs.Primary.Attributes["schedule_id"]
+
+		client, err := schedule.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+		if err != nil {
+			return err
+		}
+		req := schedule.GetScheduleOverrideRequest{
+			ScheduleIdentifierType: schedule.Id,
+			ScheduleIdentifier:     scheduleId,
+			Alias:                  alias,
+		}
+
+		_, err = client.GetScheduleOverride(context.Background(), &req)
+		if err != nil {
+			return fmt.Errorf("Bad: ScheduleOverride with alias %q (scheduleId: %q) does not exist", alias, scheduleId)
+		}
+		return nil
+	}
+}
+
+func testAccOpsGenieScheduleOverride_basic(randomUser, randomTeam, randomSchedule string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_user" "test" {
+  username  = "genietest-%s@opsgenie.com"
+  full_name = "Acceptance Test User"
+  role      = "User"
+}
+
+resource "opsgenie_team" "test" {
+  name        = "genieteam-%s"
+  description = "This team deals with all the things"
+}
+
+resource "opsgenie_schedule" "test" {
+  name = "genieschedule-%s"
+  description = "schedule test"
+  timezone = "Europe/Rome"
+  enabled = false
+  owner_team_id = "${opsgenie_team.test.id}"
+}
+
+resource "opsgenie_schedule_override" "test" {
+  schedule_id = "${opsgenie_schedule.test.id}"
+  start_date  = "2030-12-24T00:00:00Z"
+  end_date    = "2030-12-27T00:00:00Z"
+  responder {
+    type = "user"
+    id   = "${opsgenie_user.test.id}"
+  }
+}
+`, randomUser, randomTeam, randomSchedule)
+}
+
+func testAccOpsGenieScheduleOverride_complete(randomUser, randomTeam, randomSchedule, randomRotation, randomAlias string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_user" "test" {
+  username  = "genietest-%s@opsgenie.com"
+  full_name = "Acceptance Test User"
+  role      = "User"
+}
+
+resource "opsgenie_team" "test" {
+  name        = "genieteam-%s"
+  description = "This team deals with all the things"
+}
+
+resource "opsgenie_schedule" "test" {
+  name = "genieschedule-%s"
+  description = "schedule test"
+  timezone = "Europe/Rome"
+  enabled = false
+  owner_team_id = "${opsgenie_team.test.id}"
+}
+
+resource "opsgenie_schedule_rotation" "test" {
+  schedule_id = "${opsgenie_schedule.test.id}"
+  name        = "genierotation-%s"
+  start_date  = "2030-12-01T00:00:00Z"
+  type        = "weekly"
+  length      = 1
+  participant {
+    type = "user"
+    id   = "${opsgenie_user.test.id}"
+  }
+}
+
+resource "opsgenie_schedule_override" "test" {
+  schedule_id  = "${opsgenie_schedule.test.id}"
+  alias        = "genieoverride-%s"
+  start_date   = "2030-12-24T00:00:00Z"
+  end_date     = "2030-12-27T00:00:00Z"
+  rotation_ids = ["${opsgenie_schedule_rotation.test.id}"]
+  responder {
+    type = "team"
+    id   = "${opsgenie_team.test.id}"
+  }
+}
+`, randomUser, randomTeam, randomSchedule, randomRotation, randomAlias)
+}
@@ -10,17 +10,19 @@ import (
 	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/schedule"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceOpsgenieScheduleRotation() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsgenieScheduleRotationCreate,
-		Read:   handleNonExistentResource(resourceOpsgenieScheduleRotationRead),
-		Update: resourceOpsgenieScheduleRotationUpdate,
-		Delete: resourceOpsgenieScheduleRotationDelete,
+		CreateContext: resourceOpsgenieScheduleRotationCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsgenieScheduleRotationRead),
+		UpdateContext: resourceOpsgenieScheduleRotationUpdate,
+		DeleteContext: resourceOpsgenieScheduleRotationDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idParts := strings.Split(d.Id(), "/")
 				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 					return nil, fmt.Errorf("Unexpected format of ID (%q), expected schedule_id/schedule_rotation_id", d.Id())
@@ -82,10 +84,10 @@ func resourceOpsgenieScheduleRotation() *schema.Resource {
 	}
 }
 
-func resourceOpsgenieScheduleRotationCreate(d *schema.ResourceData, meta interface{}) error {
-	client, err := schedule.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieScheduleRotationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).scheduleClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	scheduleIdentiferValue := d.Get("schedule_id").(string)
 
@@ -99,7 +101,7 @@ func resourceOpsgenieScheduleRotationCreate(d *schema.ResourceData, meta interfa
 	layoutStr := "2006-01-02T15:04:05Z"
 	startDate, err := time.Parse(layoutStr, start_date)
 	if err != nil {
-		return fmt.Errorf("Cannot parse date-time")
+		return diag.Errorf("Cannot parse date-time")
 	}
 
 	createRequest := &schedule.CreateRotationRequest{
@@ -132,18 +134,18 @@ func resourceOpsgenieScheduleRotationCreate(d *schema.ResourceData, meta interfa
 
 	log.Printf("[INFO] Creating OpsGenie rotation '%s'", name)
 
-	result, err := client.CreateRotation(context.Background(), createRequest)
+	result, err := client.CreateRotation(ctx, createRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(result.Id)
 
-	return resourceOpsgenieScheduleRotationRead(d, meta)
+	return diag.FromErr(resourceOpsgenieScheduleRotationRead(ctx, d, meta))
 }
 
-func resourceOpsgenieScheduleRotationRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := schedule.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieScheduleRotationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).scheduleClient()
 	if err != nil {
 		return err
 	}
@@ -155,7 +157,7 @@ func resourceOpsgenieScheduleRotationRead(d *schema.ResourceData, meta interface
 		ScheduleIdentifierValue: scheduleIdentiferValue,
 		RotationId:              d.Id(),
 	}
-	getResponse, err := client.GetRotation(context.Background(), getRequest)
+	getResponse, err := client.GetRotation(ctx, getRequest)
 	if err != nil {
 		return err
 	}
@@ -189,10 +191,10 @@ func flattenOpsgenieScheduleRotationParticipant(input []og.Participant) []map[st
 	return participants
 }
 
-func resourceOpsgenieScheduleRotationUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := schedule.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsgenieScheduleRotationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).scheduleClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	scheduleIdentiferValue := d.Get("schedule_id").(string)
 
@@ -236,19 +238,19 @@ func resourceOpsgenieScheduleRotationUpdate(d *schema.ResourceData, meta interfa
 	}
 	log.Printf("[INFO] Updating OpsGenie schedule rotation '%s'", name)
 
-	_, err = client.UpdateRotation(context.Background(), updateRequest)
+	_, err = client.UpdateRotation(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceOpsgenieScheduleRotationDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsgenieScheduleRotationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Deleting OpsGenie schedule rotation '%s'", d.Get("name").(string))
-	client, err := schedule.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).scheduleClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	scheduleIdentiferValue := d.Get("schedule_id").(string)
 
@@ -258,9 +260,9 @@ func resourceOpsgenieScheduleRotationDelete(d *schema.ResourceData, meta interfa
 		RotationId:              d.Id(),
 	}
 
-	_, err = client.DeleteRotation(context.Background(), deleteRequest)
+	_, err = client.DeleteRotation(ctx, deleteRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -8,17 +8,19 @@ import (
 
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceOpsGenieService() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsGenieServiceCreate,
-		Read:   handleNonExistentResource(resourceOpsGenieServiceRead),
-		Update: resourceOpsGenieServiceUpdate,
-		Delete: resourceOpsGenieServiceDelete,
+		CreateContext: resourceOpsGenieServiceCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsGenieServiceRead),
+		UpdateContext: resourceOpsGenieServiceUpdate,
+		DeleteContext: resourceOpsGenieServiceDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -48,10 +50,10 @@ func resourceOpsGenieService() *schema.Resource {
 	}
 }
 
-func resourceOpsGenieServiceCreate(d *schema.ResourceData, meta interface{}) error {
-	client, err := service.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieServiceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).serviceClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	name := d.Get("name").(string)
 	teamId := d.Get("team_id").(string)
@@ -66,18 +68,18 @@ func resourceOpsGenieServiceCreate(d *schema.ResourceData, meta interface{}) err
 	}
 
 	log.Printf("[INFO] Creating OpsGenie service '%s'", name)
-	result, err := client.Create(context.Background(), createRequest)
+	result, err := client.Create(ctx, createRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(result.Id)
 
-	return resourceOpsGenieServiceRead(d, meta)
+	return diag.FromErr(resourceOpsGenieServiceRead(ctx, d, meta))
 }
 
-func resourceOpsGenieServiceRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := service.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieServiceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).serviceClient()
 	if err != nil {
 		return err
 	}
@@ -85,7 +87,7 @@ func resourceOpsGenieServiceRead(d *schema.ResourceData, meta interface{}) error
 
 	log.Printf("[INFO] Reading OpsGenie service '%s'", name)
 
-	res, err := client.Get(context.Background(), &service.GetRequest{
+	res, err := client.Get(ctx, &service.GetRequest{
 		Id: d.Id(),
 	})
 	if err != nil {
@@ -100,10 +102,10 @@ func resourceOpsGenieServiceRead(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
-func resourceOpsGenieServiceUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := service.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieServiceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).serviceClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -118,27 +120,27 @@ func resourceOpsGenieServiceUpdate(d *schema.ResourceData, meta interface{}) err
 		Tags:        tags,
 	}
 
-	_, err = client.Update(context.Background(), updateRequest)
+	_, err = client.Update(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceOpsGenieServiceDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsGenieServiceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Deleting OpsGenie service '%s'", d.Get("name").(string))
-	client, err := service.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).serviceClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	deleteRequest := &service.DeleteRequest{
 		Id: d.Id(),
 	}
 
-	_, err = client.Delete(context.Background(), deleteRequest)
+	_, err = client.Delete(ctx, deleteRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
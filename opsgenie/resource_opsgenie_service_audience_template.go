@@ -0,0 +1,293 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/service"
+)
+
+func resourceOpsGenieServiceAudienceTemplate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOpsGenieServiceAudienceTemplateCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsGenieServiceAudienceTemplateRead),
+		UpdateContext: resourceOpsGenieServiceAudienceTemplateUpdate,
+		DeleteContext: resourceOpsGenieServiceAudienceTemplateDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return validateServiceAudienceTemplateConditions(d.GetRawConfig())
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"service_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 130),
+			},
+			"responder": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"teams": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							MaxItems: 50,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"individuals": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							MaxItems: 50,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"stakeholder": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"individuals": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"condition_match_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringInSlice([]string{"match-any-condition", "match-all-conditions"}, false),
+						},
+						"conditions": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"match_field": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice([]string{"country", "state", "city", "zipCode", "line", "tag", "customProperty"}, false),
+									},
+									"key": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Key of the custom property, required if 'match_field' is set as 'customProperty'",
+									},
+									"value": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringLenBetween(1, 15000),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateServiceAudienceTemplateConditions rejects custom property
+// conditions without a key at plan time, instead of having Opsgenie reject
+// them while applying. Values not known yet are validated on the next plan.
+func validateServiceAudienceTemplateConditions(config cty.Value) error {
+	for _, condition := range conditionsBlockValues(config, []string{"stakeholder", "conditions"}) {
+		if condition.IsNull() || !condition.IsKnown() {
+			continue
+		}
+		matchField, matchFieldKnown := conditionString(condition.GetAttr("match_field"))
+		key, keyKnown := conditionString(condition.GetAttr("key"))
+		if matchFieldKnown && keyKnown && matchField == "customProperty" && key == "" {
+			return fmt.Errorf("stakeholder.conditions: key is required when match_field is customProperty")
+		}
+	}
+	return nil
+}
+
+// serviceAudienceTemplateRequest is a service.UpdateAudienceTemplateRequest
+// sending empty lists as well. Opsgenie keeps the lists left out of an update,
+// so teams, individuals and conditions couldn't be removed otherwise. The
+// embedded request validates the update and locates the template.
+type serviceAudienceTemplateRequest struct {
+	service.UpdateAudienceTemplateRequest
+	Responder   serviceAudienceResponder   `json:"responder"`
+	Stakeholder serviceAudienceStakeholder `json:"stakeholder"`
+}
+
+type serviceAudienceResponder struct {
+	Teams       []string `json:"teams"`
+	Individuals []string `json:"individuals"`
+}
+
+type serviceAudienceStakeholder struct {
+	Individuals        []string                         `json:"individuals"`
+	ConditionMatchType og.ConditionMatchType            `json:"conditionMatchType,omitempty"`
+	Conditions         []service.ConditionOfStakeholder `json:"conditions"`
+}
+
+func newServiceAudienceTemplateRequest(request service.UpdateAudienceTemplateRequest) *serviceAudienceTemplateRequest {
+	return &serviceAudienceTemplateRequest{
+		UpdateAudienceTemplateRequest: request,
+		Responder: serviceAudienceResponder{
+			Teams:       append([]string{}, request.Responder.Teams...),
+			Individuals: append([]string{}, request.Responder.Individuals...),
+		},
+		Stakeholder: serviceAudienceStakeholder{
+			Individuals:        append([]string{}, request.Stakeholder.Individuals...),
+			ConditionMatchType: request.Stakeholder.ConditionMatchType,
+			Conditions:         append([]service.ConditionOfStakeholder{}, request.Stakeholder.Conditions...),
+		},
+	}
+}
+
+func resourceOpsGenieServiceAudienceTemplateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	serviceId := d.Get("service_id").(string)
+	if err := updateOpsGenieServiceAudienceTemplate(ctx, d, meta, serviceId); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(serviceId)
+
+	return diag.FromErr(resourceOpsGenieServiceAudienceTemplateRead(ctx, d, meta))
+}
+
+func resourceOpsGenieServiceAudienceTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).serviceClient()
+	if err != nil {
+		return err
+	}
+
+	result, err := client.GetAudienceTemplate(ctx, &service.GetAudienceTemplateRequest{
+		ServiceId: d.Id(),
+	})
+	if err != nil {
+		return err
+	}
+
+	d.Set("service_id", d.Id())
+	d.Set("responder", flattenOpsGenieServiceAudienceResponder(result.Responder))
+	d.Set("stakeholder", flattenOpsGenieServiceAudienceStakeholder(result.Stakeholder))
+
+	return nil
+}
+
+func resourceOpsGenieServiceAudienceTemplateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := updateOpsGenieServiceAudienceTemplate(ctx, d, meta, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(resourceOpsGenieServiceAudienceTemplateRead(ctx, d, meta))
+}
+
+// resourceOpsGenieServiceAudienceTemplateDelete empties the audience template,
+// as a service always has one.
+func resourceOpsGenieServiceAudienceTemplateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Deleting OpsGenie audience template of service '%s'", d.Id())
+	deleteRequest := newServiceAudienceTemplateRequest(service.UpdateAudienceTemplateRequest{
+		ServiceId: d.Id(),
+	})
+
+	err := meta.(*OpsgenieClient).client.Exec(ctx, deleteRequest, &service.UpdateAudienceTemplateResult{})
+	if err != nil {
+		apiError, ok := err.(*ogClient.ApiError)
+		if !ok || apiError.StatusCode != 404 {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func updateOpsGenieServiceAudienceTemplate(ctx context.Context, d *schema.ResourceData, meta interface{}, serviceId string) error {
+	updateRequest := newServiceAudienceTemplateRequest(service.UpdateAudienceTemplateRequest{
+		ServiceId:   serviceId,
+		Responder:   expandOpsGenieServiceAudienceResponder(d.Get("responder").([]interface{})),
+		Stakeholder: expandOpsGenieServiceAudienceStakeholder(d.Get("stakeholder").([]interface{})),
+	})
+
+	log.Printf("[INFO] Updating OpsGenie audience template of service '%s'", serviceId)
+	return meta.(*OpsgenieClient).client.Exec(ctx, updateRequest, &service.UpdateAudienceTemplateResult{})
+}
+
+func expandOpsGenieServiceAudienceResponder(input []interface{}) service.ResponderOfAudience {
+	responder := service.ResponderOfAudience{}
+	for _, v := range input {
+		if v == nil {
+			continue
+		}
+		config := v.(map[string]interface{})
+		responder.Teams = flattenSet(config["teams"].(*schema.Set))
+		responder.Individuals = flattenSet(config["individuals"].(*schema.Set))
+	}
+	return responder
+}
+
+func expandOpsGenieServiceAudienceStakeholder(input []interface{}) service.StakeholderOfAudience {
+	stakeholder := service.StakeholderOfAudience{}
+	for _, v := range input {
+		if v == nil {
+			continue
+		}
+		config := v.(map[string]interface{})
+		stakeholder.Individuals = flattenSet(config["individuals"].(*schema.Set))
+		stakeholder.ConditionMatchType = og.ConditionMatchType(config["condition_match_type"].(string))
+		for _, c := range config["conditions"].([]interface{}) {
+			condition := c.(map[string]interface{})
+			stakeholder.Conditions = append(stakeholder.Conditions, service.ConditionOfStakeholder{
+				MatchField: service.MatchField(condition["match_field"].(string)),
+				Key:        condition["key"].(string),
+				Value:      condition["value"].(string),
+			})
+		}
+	}
+	return stakeholder
+}
+
+func flattenOpsGenieServiceAudienceResponder(input service.ResponderOfAudience) []map[string]interface{} {
+	if len(input.Teams) == 0 && len(input.Individuals) == 0 {
+		return nil
+	}
+	return []map[string]interface{}{{
+		"teams":       input.Teams,
+		"individuals": input.Individuals,
+	}}
+}
+
+func flattenOpsGenieServiceAudienceStakeholder(input service.StakeholderOfAudience) []map[string]interface{} {
+	if len(input.Individuals) == 0 && len(input.Conditions) == 0 {
+		return nil
+	}
+	conditions := make([]map[string]interface{}, 0, len(input.Conditions))
+	for _, c := range input.Conditions {
+		conditions = append(conditions, map[string]interface{}{
+			"match_field": string(c.MatchField),
+			"key":         c.Key,
+			"value":       c.Value,
+		})
+	}
+	return []map[string]interface{}{{
+		"individuals":          input.Individuals,
+		"condition_match_type": string(input.ConditionMatchType),
+		"conditions":           conditions,
+	}}
+}
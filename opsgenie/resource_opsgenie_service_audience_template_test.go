@@ -0,0 +1,197 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/service"
+)
+
+func TestAccOpsGenieServiceAudienceTemplate_basic(t *testing.T) {
+	randomTeam := acctest.RandString(6)
+	randomService := acctest.RandString(6)
+	randomUser := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckOpsGenieServiceAudienceTemplateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpsGenieServiceAudienceTemplate_complete(randomTeam, randomService, randomUser),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieServiceAudienceTemplateExists("opsgenie_service_audience_template.test"),
+					resource.TestCheckResourceAttr("opsgenie_service_audience_template.test", "responder.0.teams.#", "1"),
+					resource.TestCheckResourceAttr("opsgenie_service_audience_template.test", "responder.0.individuals.#", "1"),
+					resource.TestCheckResourceAttr("opsgenie_service_audience_template.test", "stakeholder.0.condition_match_type", "match-any-condition"),
+					resource.TestCheckResourceAttr("opsgenie_service_audience_template.test", "stakeholder.0.conditions.#", "2"),
+				),
+			},
+			{
+				Config: testAccOpsGenieServiceAudienceTemplate_basic(randomTeam, randomService, randomUser),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieServiceAudienceTemplateExists("opsgenie_service_audience_template.test"),
+					resource.TestCheckResourceAttr("opsgenie_service_audience_template.test", "responder.0.teams.#", "1"),
+					resource.TestCheckResourceAttr("opsgenie_service_audience_template.test", "responder.0.individuals.#", "0"),
+					resource.TestCheckResourceAttr("opsgenie_service_audience_template.test", "stakeholder.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "opsgenie_service_audience_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testCheckOpsGenieServiceAudienceTemplateDestroy checks that the audience
+// templates of the services still around were emptied.
+func TestValidateServiceAudienceTemplateConditions(t *testing.T) {
+	config := func(matchField, key cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"stakeholder": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"conditions": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"match_field": matchField,
+					"key":         key,
+					"value":       cty.StringVal("test"),
+				})}),
+			})}),
+		})
+	}
+	null := cty.NullVal(cty.String)
+
+	cases := []struct {
+		name   string
+		config cty.Value
+		err    string
+	}{
+		{name: "country", config: config(cty.StringVal("country"), null)},
+		{name: "custom property", config: config(cty.StringVal("customProperty"), cty.StringVal("team"))},
+		{name: "custom property without key", config: config(cty.StringVal("customProperty"), null), err: "key is required when match_field is customProperty"},
+		{name: "custom property with empty key", config: config(cty.StringVal("customProperty"), cty.StringVal("")), err: "key is required when match_field is customProperty"},
+		{name: "custom property with unknown key", config: config(cty.StringVal("customProperty"), cty.UnknownVal(cty.String))},
+	}
+	for _, c := range cases {
+		err := validateServiceAudienceTemplateConditions(c.config)
+		if c.err == "" {
+			if err != nil {
+				t.Errorf("%s: expected no error, got %s", c.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s: expected an error containing %q, got %v", c.name, c.err, err)
+		}
+	}
+}
+
+func testCheckOpsGenieServiceAudienceTemplateDestroy(s *terraform.State) error {
+	client, err := service.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "opsgenie_service_audience_template" {
+			continue
+		}
+		result, err := client.GetAudienceTemplate(context.Background(), &service.GetAudienceTemplateRequest{
+			ServiceId: rs.Primary.ID,
+		})
+		if err != nil {
+			continue
+		}
+		if len(result.Responder.Teams) > 0 || len(result.Responder.Individuals) > 0 || len(result.Stakeholder.Individuals) > 0 || len(result.Stakeholder.Conditions) > 0 {
+			return fmt.Errorf("Audience template of service %s is not empty", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testCheckOpsGenieServiceAudienceTemplateExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		client, err := service.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+		if err != nil {
+			return err
+		}
+		_, err = client.GetAudienceTemplate(context.Background(), &service.GetAudienceTemplateRequest{
+			ServiceId: rs.Primary.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("Bad: Audience template of service %q does not exist", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccOpsGenieServiceAudienceTemplate_basic(randomTeam, randomService, randomUser string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_user" "test" {
+  username  = "genietest-%s@opsgenie.com"
+  full_name = "Test User"
+  role      = "User"
+}
+resource "opsgenie_team" "test" {
+  name        = "genieteam-%s"
+  description = "This team deals with all the things"
+}
+resource "opsgenie_service" "test" {
+  name    = "genietest-service-%s"
+  team_id = opsgenie_team.test.id
+}
+resource "opsgenie_service_audience_template" "test" {
+  service_id = opsgenie_service.test.id
+  responder {
+    teams = [opsgenie_team.test.id]
+  }
+}
+`, randomUser, randomTeam, randomService)
+}
+
+func testAccOpsGenieServiceAudienceTemplate_complete(randomTeam, randomService, randomUser string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_user" "test" {
+  username  = "genietest-%s@opsgenie.com"
+  full_name = "Test User"
+  role      = "User"
+}
+resource "opsgenie_team" "test" {
+  name        = "genieteam-%s"
+  description = "This team deals with all the things"
+}
+resource "opsgenie_service" "test" {
+  name    = "genietest-service-%s"
+  team_id = opsgenie_team.test.id
+}
+resource "opsgenie_service_audience_template" "test" {
+  service_id = opsgenie_service.test.id
+  responder {
+    teams       = [opsgenie_team.test.id]
+    individuals = [opsgenie_user.test.id]
+  }
+  stakeholder {
+    individuals          = [opsgenie_user.test.id]
+    condition_match_type = "match-any-condition"
+    conditions {
+      match_field = "tag"
+      value       = "executive"
+    }
+    conditions {
+      match_field = "customProperty"
+      key         = "department"
+      value       = "support"
+    }
+  }
+}
+`, randomUser, randomTeam, randomService)
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
@@ -16,12 +17,14 @@ import (
 
 func resourceOpsGenieServiceIncidentRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsGenieServiceIncidentRuleCreate,
-		Read:   handleNonExistentResource(resourceOpsGenieServiceIncidentRuleRead),
-		Update: resourceOpsGenieServiceIncidentRuleUpdate,
-		Delete: resourceOpsGenieServiceIncidentRuleDelete,
+		CreateContext: resourceOpsGenieServiceIncidentRuleCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsGenieServiceIncidentRuleRead),
+		UpdateContext: resourceOpsGenieServiceIncidentRuleUpdate,
+		DeleteContext: resourceOpsGenieServiceIncidentRuleDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: validateConditionsDiff(conditionsBlock{path: []string{"incident_rule"}, matchType: "condition_match_type"}),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idParts := strings.Split(d.Id(), "/")
 				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 					return nil, fmt.Errorf("Unexpected format of ID (%q), expected service_id/service_incident_rule_id", d.Id())
@@ -46,108 +49,73 @@ func resourceOpsGenieServiceIncidentRule() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Default:      "match-all",
-							ValidateFunc: validation.StringInSlice([]string{"match-all", "match-any-condition", "match-all-conditions"}, false),
+							ValidateFunc: validation.StringInSlice(conditionMatchTypes, false),
 						},
-						"conditions": {
-							Type:     schema.TypeSet,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"field": {
-										Type:     schema.TypeString,
-										Required: true,
-										ValidateFunc: validation.StringInSlice([]string{
-											"message", "description", "tags",
-											"extra-properties", "recipients", "teams", "priority",
-											"alias", "source", "entity", "actions", "details",
-										}, false),
-									},
-									"operation": {
-										Type:     schema.TypeString,
-										Required: true,
-										ValidateFunc: validation.StringInSlice([]string{
-											"matches", "contains", "starts-with", "ends-with", "equals", "contains-key",
-											"contains-value", "greater-than", "less-than", "is-empty", "equals-ignore-whitespace",
-										}, false),
-									},
-									"key": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "If 'field' is set as 'extra-properties', key could be used for key-value pair",
-									},
-									"not": {
-										Type:        schema.TypeBool,
-										Optional:    true,
-										Description: "Indicates behaviour of the given operation. Default value is false",
-										Default:     false,
-									},
-									"expected_value": {
-										Type:         schema.TypeString,
-										Optional:     true,
-										Description:  "User defined value that will be compared with alert field according to the operation. Default value is empty string",
-										ValidateFunc: validation.StringLenBetween(1, 15000),
-									},
-								},
+						"conditions":          serviceIncidentRuleConditionsSchema(),
+						"incident_properties": serviceIncidentPropertiesSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+// serviceIncidentPropertiesSchema returns the schema of the properties of the
+// incidents created by service incident rules and incident templates.
+func serviceIncidentPropertiesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"message": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringLenBetween(1, 130),
+				},
+				"tags": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"details": {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"description": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "{{description}}",
+					ValidateFunc: validation.StringLenBetween(1, 10000),
+				},
+				"priority": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"P1", "P2", "P3", "P4", "P5"}, false),
+				},
+				"stakeholder_properties": {
+					Type:     schema.TypeList,
+					Required: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"enable": {
+								Type:     schema.TypeBool,
+								Optional: true,
+								Default:  true,
 							},
-						},
-						"incident_properties": {
-							Type:     schema.TypeList,
-							Required: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"message": {
-										Type:         schema.TypeString,
-										Required:     true,
-										ValidateFunc: validation.StringLenBetween(1, 130),
-									},
-									"tags": {
-										Type:     schema.TypeSet,
-										Optional: true,
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
-									},
-									"details": {
-										Type:     schema.TypeMap,
-										Optional: true,
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
-									},
-									"description": {
-										Type:         schema.TypeString,
-										Optional:     true,
-										Default:      "{{description}}",
-										ValidateFunc: validation.StringLenBetween(1, 10000),
-									},
-									"priority": {
-										Type:         schema.TypeString,
-										Required:     true,
-										ValidateFunc: validation.StringInSlice([]string{"P1", "P2", "P3", "P4", "P5"}, false),
-									},
-									"stakeholder_properties": {
-										Type:     schema.TypeList,
-										Required: true,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"enable": {
-													Type:     schema.TypeBool,
-													Optional: true,
-													Default:  true,
-												},
-												"message": {
-													Type:     schema.TypeString,
-													Required: true,
-												},
-												"description": {
-													Type:         schema.TypeString,
-													Optional:     true,
-													ValidateFunc: validation.StringLenBetween(1, 15000),
-												},
-											},
-										},
-									},
-								},
+							"message": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"description": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								ValidateFunc: validation.StringLenBetween(1, 15000),
 							},
 						},
 					},
@@ -157,10 +125,20 @@ func resourceOpsGenieServiceIncidentRule() *schema.Resource {
 	}
 }
 
-func resourceOpsGenieServiceIncidentRuleCreate(d *schema.ResourceData, meta interface{}) error {
-	client, err := service.NewClient(meta.(*OpsgenieClient).client.Config)
+// serviceIncidentRuleConditionsSchema returns the condition schema shared with
+// policies and routing rules, incident rule conditions have no order though.
+func serviceIncidentRuleConditionsSchema() *schema.Schema {
+	s := conditionsSchema(schema.TypeSet, criteriaConditionFields)
+	conditionSchema := s.Elem.(*schema.Resource).Schema
+	delete(conditionSchema, "order")
+	conditionSchema["expected_value"].ValidateFunc = validation.StringLenBetween(1, 15000)
+	return s
+}
+
+func resourceOpsGenieServiceIncidentRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).serviceClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	service_id := d.Get("service_id").(string)
@@ -172,23 +150,23 @@ func resourceOpsGenieServiceIncidentRuleCreate(d *schema.ResourceData, meta inte
 	for _, v := range incident_rule {
 		config := v.(map[string]interface{})
 		createRequest.ConditionMatchType = og.ConditionMatchType(config["condition_match_type"].(string))
-		createRequest.Conditions = expandOpsGenieServiceIncidentRuleConditions(config["conditions"].(*schema.Set))
+		createRequest.Conditions = expandOpsgenieConditions(config["conditions"].(*schema.Set).List())
 		createRequest.IncidentProperties = expandOpsGenieServiceIncidentRuleIncidentProperties(config["incident_properties"].([]interface{}))
 	}
 
 	log.Printf("[INFO] Creating OpsGenie Service Incident Rule for service '%s'", d.Get("service_id").(string))
-	result, err := client.CreateIncidentRule(context.Background(), createRequest)
+	result, err := client.CreateIncidentRule(ctx, createRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(result.Id)
 
-	return resourceOpsGenieServiceIncidentRuleRead(d, meta)
+	return diag.FromErr(resourceOpsGenieServiceIncidentRuleRead(ctx, d, meta))
 }
 
-func resourceOpsGenieServiceIncidentRuleRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := service.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieServiceIncidentRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).serviceClient()
 	if err != nil {
 		return err
 	}
@@ -197,7 +175,7 @@ func resourceOpsGenieServiceIncidentRuleRead(d *schema.ResourceData, meta interf
 
 	log.Printf("[INFO] Reading OpsGenie Service Incident Rule for service: '%s' for rule ID: '%s'", service_id, incident_rule_id)
 
-	incident_rule_res, err := client.GetIncidentRules(context.Background(), &service.GetIncidentRulesRequest{
+	incident_rule_res, err := client.GetIncidentRules(ctx, &service.GetIncidentRulesRequest{
 		ServiceId: service_id,
 	})
 	if err != nil {
@@ -226,10 +204,10 @@ func resourceOpsGenieServiceIncidentRuleRead(d *schema.ResourceData, meta interf
 	return nil
 }
 
-func resourceOpsGenieServiceIncidentRuleUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := service.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieServiceIncidentRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).serviceClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	service_id := d.Get("service_id").(string)
@@ -244,49 +222,43 @@ func resourceOpsGenieServiceIncidentRuleUpdate(d *schema.ResourceData, meta inte
 	for _, v := range incident_rule {
 		config := v.(map[string]interface{})
 		updateRequest.ConditionMatchType = og.ConditionMatchType(config["condition_match_type"].(string))
-		updateRequest.Conditions = expandOpsGenieServiceIncidentRuleConditions(config["conditions"].(*schema.Set))
+		updateRequest.Conditions = expandOpsgenieConditions(config["conditions"].(*schema.Set).List())
 		updateRequest.IncidentProperties = expandOpsGenieServiceIncidentRuleIncidentProperties(config["incident_properties"].([]interface{}))
 	}
 
 	log.Printf("[INFO] Updating Service Incident Rule for service: '%s' for rule ID: '%s'", service_id, incident_rule_id)
-	_, err = client.UpdateIncidentRule(context.Background(), updateRequest)
+	_, err = client.UpdateIncidentRule(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceOpsGenieServiceIncidentRuleDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsGenieServiceIncidentRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service_id := d.Get("service_id").(string)
 	incident_rule_id := d.Id()
 
 	log.Printf("[INFO] Deleting OpsGenie ervice Incident Rule for service: '%s' for rule ID: '%s'", service_id, incident_rule_id)
-	client, err := service.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).serviceClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	deleteRequest := &service.DeleteIncidentRuleRequest{
 		ServiceId:      service_id,
 		IncidentRuleId: incident_rule_id,
 	}
 
-	_, err = client.DeleteIncidentRule(context.Background(), deleteRequest)
+	_, err = client.DeleteIncidentRule(ctx, deleteRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
 func flattenOpsGenieServiceIncidentRules(input service.IncidentRuleResult) []map[string]interface{} {
 	incident_rule := make(map[string]interface{})
-	conditions_list := make([]map[string]interface{}, 0, len(input.Conditions))
-
-	for _, v := range input.Conditions {
-		conditions := flattenOpsGenieServiceIncidentRuleConditions(v)
-		conditions_list = append(conditions_list, conditions)
-	}
-	incident_rule["conditions"] = conditions_list
+	incident_rule["conditions"] = flattenOpsgenieConditions(input.Conditions, false)
 	incident_rule["condition_match_type"] = input.ConditionMatchType
 	incident_rule["incident_properties"] = flattenOpsGenieServiceIncidentRuleIncidentProperties(input.IncidentProperties)
 
@@ -294,32 +266,6 @@ func flattenOpsGenieServiceIncidentRules(input service.IncidentRuleResult) []map
 
 }
 
-func expandOpsGenieServiceIncidentRuleConditions(input *schema.Set) []og.Condition {
-	conditions := make([]og.Condition, 0, input.Len())
-	if input == nil {
-		return conditions
-	}
-
-	for _, v := range input.List() {
-		condition := og.Condition{}
-		config := v.(map[string]interface{})
-		not_value := config["not"].(bool)
-		condition.Field = og.ConditionFieldType(config["field"].(string))
-		condition.Operation = og.ConditionOperation(config["operation"].(string))
-		condition.IsNot = &not_value
-		condition.ExpectedValue = config["expected_value"].(string)
-		if condition.Field == og.ExtraProperties {
-			key := config["key"].(string)
-			if key != "" {
-				condition.Key = config["key"].(string)
-			}
-		}
-		conditions = append(conditions, condition)
-	}
-
-	return conditions
-}
-
 func expandOpsGenieServiceIncidentRuleIncidentProperties(input []interface{}) service.IncidentProperties {
 	incident_properties := service.IncidentProperties{}
 
@@ -383,20 +329,6 @@ func flattenOpsgenieServiceIncidentRuleRequestDetails(input map[string]interface
 	return details
 }
 
-func flattenOpsGenieServiceIncidentRuleConditions(input og.Condition) map[string]interface{} {
-	condition := make(map[string]interface{})
-
-	condition["field"] = input.Field
-	condition["operation"] = input.Operation
-	condition["not"] = *input.IsNot
-	condition["expected_value"] = input.ExpectedValue
-	if input.Key != "" {
-		condition["key"] = input.Key
-	}
-
-	return condition
-}
-
 func flattenOpsGenieServiceIncidentRuleIncidentProperties(input service.IncidentProperties) []map[string]interface{} {
 	incident_properties := make(map[string]interface{})
 	if len(input.Tags) > 0 {
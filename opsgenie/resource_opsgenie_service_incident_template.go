@@ -0,0 +1,143 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/service"
+)
+
+func resourceOpsGenieServiceIncidentTemplate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOpsGenieServiceIncidentTemplateCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsGenieServiceIncidentTemplateRead),
+		UpdateContext: resourceOpsGenieServiceIncidentTemplateUpdate,
+		DeleteContext: resourceOpsGenieServiceIncidentTemplateDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idParts := strings.Split(d.Id(), "/")
+				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+					return nil, fmt.Errorf("Unexpected format of ID (%q), expected service_id/service_incident_template_id", d.Id())
+				}
+				d.Set("service_id", idParts[0])
+				d.SetId(idParts[1])
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"service_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 130),
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"incident_properties": serviceIncidentPropertiesSchema(),
+		},
+	}
+}
+
+func resourceOpsGenieServiceIncidentTemplateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).serviceClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serviceId := d.Get("service_id").(string)
+	createRequest := &service.CreateIncidentTemplateRequest{
+		ServiceId: serviceId,
+		IncidentTemplate: service.IncidentTemplateRequest{
+			Name:               d.Get("name").(string),
+			IncidentProperties: expandOpsGenieServiceIncidentRuleIncidentProperties(d.Get("incident_properties").([]interface{})),
+		},
+	}
+
+	log.Printf("[INFO] Creating OpsGenie Service Incident Template '%s' for service '%s'", createRequest.IncidentTemplate.Name, serviceId)
+	result, err := client.CreateIncidentTemplate(ctx, createRequest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(result.Id)
+
+	return diag.FromErr(resourceOpsGenieServiceIncidentTemplateRead(ctx, d, meta))
+}
+
+func resourceOpsGenieServiceIncidentTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).serviceClient()
+	if err != nil {
+		return err
+	}
+	serviceId := d.Get("service_id").(string)
+
+	log.Printf("[INFO] Reading OpsGenie Service Incident Template '%s' of service '%s'", d.Id(), serviceId)
+	result, err := client.GetIncidentTemplates(ctx, &service.GetIncidentTemplatesRequest{
+		ServiceId: serviceId,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, template := range result.IncidentTemplates {
+		if template.Id == d.Id() {
+			d.Set("service_id", serviceId)
+			d.Set("name", template.Name)
+			d.Set("incident_properties", flattenOpsGenieServiceIncidentRuleIncidentProperties(template.IncidentProperties))
+			return nil
+		}
+	}
+
+	log.Printf("[WARN] Removing Service Incident Template '%s' because it's gone", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceOpsGenieServiceIncidentTemplateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).serviceClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	updateRequest := &service.UpdateIncidentTemplateRequest{
+		ServiceId:          d.Get("service_id").(string),
+		IncidentTemplateId: d.Id(),
+		Name:               d.Get("name").(string),
+		IncidentProperties: expandOpsGenieServiceIncidentRuleIncidentProperties(d.Get("incident_properties").([]interface{})),
+	}
+
+	log.Printf("[INFO] Updating OpsGenie Service Incident Template '%s' of service '%s'", d.Id(), updateRequest.ServiceId)
+	_, err = client.UpdateIncidentTemplate(ctx, updateRequest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(resourceOpsGenieServiceIncidentTemplateRead(ctx, d, meta))
+}
+
+func resourceOpsGenieServiceIncidentTemplateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).serviceClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	serviceId := d.Get("service_id").(string)
+
+	log.Printf("[INFO] Deleting OpsGenie Service Incident Template '%s' of service '%s'", d.Id(), serviceId)
+	_, err = client.DeleteIncidentTemplate(ctx, &service.DeleteIncidentTemplateRequest{
+		ServiceId:          serviceId,
+		IncidentTemplateId: d.Id(),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
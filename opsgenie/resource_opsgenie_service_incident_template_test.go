@@ -0,0 +1,132 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/service"
+)
+
+func TestAccOpsGenieServiceIncidentTemplate_basic(t *testing.T) {
+	randomTeam := acctest.RandString(6)
+	randomService := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckOpsGenieServiceIncidentTemplateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpsGenieServiceIncidentTemplate_basic(randomTeam, randomService, "P3"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieServiceIncidentTemplateExists("opsgenie_service_incident_template.test"),
+					resource.TestCheckResourceAttr("opsgenie_service_incident_template.test", "incident_properties.0.priority", "P3"),
+					resource.TestCheckResourceAttr("opsgenie_service_incident_template.test", "incident_properties.0.stakeholder_properties.0.message", "Message for stakeholders"),
+				),
+			},
+			{
+				Config: testAccOpsGenieServiceIncidentTemplate_basic(randomTeam, randomService, "P1"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieServiceIncidentTemplateExists("opsgenie_service_incident_template.test"),
+					resource.TestCheckResourceAttr("opsgenie_service_incident_template.test", "incident_properties.0.priority", "P1"),
+				),
+			},
+			{
+				ResourceName:      "opsgenie_service_incident_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["opsgenie_service_incident_template.test"]
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["service_id"], rs.Primary.ID), nil
+				},
+			},
+		},
+	})
+}
+
+func testCheckOpsGenieServiceIncidentTemplateDestroy(s *terraform.State) error {
+	client, err := service.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "opsgenie_service_incident_template" {
+			continue
+		}
+		result, err := client.GetIncidentTemplates(context.Background(), &service.GetIncidentTemplatesRequest{
+			ServiceId: rs.Primary.Attributes["service_id"],
+		})
+		if err != nil {
+			if apiErr, ok := err.(*ogClient.ApiError); ok && apiErr.StatusCode == 404 {
+				continue
+			}
+			return err
+		}
+		for _, template := range result.IncidentTemplates {
+			if template.Id == rs.Primary.ID {
+				return fmt.Errorf("Service incident template %s still exists", rs.Primary.ID)
+			}
+		}
+	}
+	return nil
+}
+
+func testCheckOpsGenieServiceIncidentTemplateExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		serviceId := rs.Primary.Attributes["service_id"]
+
+		client, err := service.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+		if err != nil {
+			return err
+		}
+		result, err := client.GetIncidentTemplates(context.Background(), &service.GetIncidentTemplatesRequest{
+			ServiceId: serviceId,
+		})
+		if err != nil {
+			return fmt.Errorf("Bad: Service ID %q does not exist", serviceId)
+		}
+		for _, template := range result.IncidentTemplates {
+			if template.Id == rs.Primary.ID {
+				return nil
+			}
+		}
+		return fmt.Errorf("Bad: Service incident template %q of service %q does not exist", rs.Primary.ID, serviceId)
+	}
+}
+
+func testAccOpsGenieServiceIncidentTemplate_basic(randomTeam, randomService, priority string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_team" "test" {
+  name        = "genieteam-%s"
+  description = "This team deals with all the things"
+}
+resource "opsgenie_service" "test" {
+  name    = "genietest-service-%s"
+  team_id = opsgenie_team.test.id
+}
+resource "opsgenie_service_incident_template" "test" {
+  service_id = opsgenie_service.test.id
+  name       = "genietest-incident-template"
+  incident_properties {
+    message  = "This is a test message"
+    priority = "%s"
+    tags     = ["outage"]
+    details = {
+      region = "eu"
+    }
+    stakeholder_properties {
+      message     = "Message for stakeholders"
+      description = "Description for stakeholders"
+    }
+  }
+}
+`, randomTeam, randomService, priority)
+}
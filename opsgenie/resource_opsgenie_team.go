@@ -3,26 +3,33 @@ package opsgenie
 import (
 	"context"
 	"errors"
-	"github.com/opsgenie/opsgenie-go-sdk-v2/client"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/escalation"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/schedule"
 	"log"
+	"time"
 
 	"fmt"
 	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/team"
 )
 
 func resourceOpsGenieTeam() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsGenieTeamCreate,
-		Read:   handleNonExistentResource(resourceOpsGenieTeamRead),
-		Update: resourceOpsGenieTeamUpdate,
-		Delete: resourceOpsGenieTeamDelete,
+		CreateContext: resourceOpsGenieTeamCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsGenieTeamRead),
+		UpdateContext: resourceOpsGenieTeamUpdate,
+		DeleteContext: resourceOpsGenieTeamDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importStateByIdOrName("team", lookupOpsgenieTeam(team.Id), lookupOpsgenieTeam(team.Name)),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -69,10 +76,10 @@ func resourceOpsGenieTeam() *schema.Resource {
 	}
 }
 
-func resourceOpsGenieTeamCreate(d *schema.ResourceData, meta interface{}) error {
-	client, err := team.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieTeamCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).teamClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -88,9 +95,9 @@ func resourceOpsGenieTeamCreate(d *schema.ResourceData, meta interface{}) error
 
 	log.Printf("[INFO] Creating OpsGenie team %q", name)
 
-	_, err = client.Create(context.Background(), createRequest)
+	_, err = client.Create(ctx, createRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	getRequest := &team.GetTeamRequest{
@@ -98,9 +105,9 @@ func resourceOpsGenieTeamCreate(d *schema.ResourceData, meta interface{}) error
 		IdentifierValue: name,
 	}
 
-	getResponse, err := client.Get(context.Background(), getRequest)
+	getResponse, err := client.Get(ctx, getRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(getResponse.Id)
@@ -108,26 +115,26 @@ func resourceOpsGenieTeamCreate(d *schema.ResourceData, meta interface{}) error
 	shouldDeleteDefaultResources := d.Get("delete_default_resources").(bool)
 
 	if shouldDeleteDefaultResources {
-		err = findAndUpdateDefaultRoutingRule(name, meta.(*OpsgenieClient).client.Config)
+		err = findAndUpdateDefaultRoutingRule(ctx, name, meta)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
-		err := findAndDeleteDefaultEscalation(name, meta.(*OpsgenieClient).client.Config)
+		err := findAndDeleteDefaultEscalation(ctx, name, meta)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
-		err = findAndDeleteDefaultSchedule(name, meta.(*OpsgenieClient).client.Config)
+		err = findAndDeleteDefaultSchedule(ctx, name, meta)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
-	return resourceOpsGenieTeamRead(d, meta)
+	return diag.FromErr(resourceOpsGenieTeamRead(ctx, d, meta))
 }
 
-func resourceOpsGenieTeamRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := team.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieTeamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).teamClient()
 	if err != nil {
 		return err
 	}
@@ -139,7 +146,7 @@ func resourceOpsGenieTeamRead(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[INFO] Retrieving state of OpsGenie team '%s'", d.Get("name"))
 
-	getResponse, err := client.Get(context.Background(), getRequest)
+	getResponse, err := client.Get(ctx, getRequest)
 	if err != nil {
 		return err
 	}
@@ -154,10 +161,10 @@ func resourceOpsGenieTeamRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceOpsGenieTeamUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := team.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieTeamUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).teamClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -174,28 +181,28 @@ func resourceOpsGenieTeamUpdate(d *schema.ResourceData, meta interface{}) error
 
 	log.Printf("[INFO] Updating OpsGenie team '%s'", name)
 
-	_, err = client.Update(context.Background(), updateRequest)
+	_, err = client.Update(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceOpsGenieTeamDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsGenieTeamDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Deleting OpsGenie team '%s'", d.Get("name").(string))
-	client, err := team.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).teamClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	deleteRequest := &team.DeleteTeamRequest{
 		IdentifierType:  team.Id,
 		IdentifierValue: d.Id(),
 	}
 
-	_, err = client.Delete(context.Background(), deleteRequest)
+	_, err = client.Delete(ctx, deleteRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -255,13 +262,13 @@ func validateOpsGenieTeamName(v interface{}, k string) (ws []string, errors []er
 	return
 }
 
-func findAndDeleteDefaultSchedule(teamName string, config *client.Config) error {
-	scheduleClient, err := schedule.NewClient(config)
+func findAndDeleteDefaultSchedule(ctx context.Context, teamName string, meta interface{}) error {
+	scheduleClient, err := meta.(*OpsgenieClient).scheduleClient()
 	if err != nil {
 		return err
 	}
 	expand := true
-	res, err := scheduleClient.List(context.Background(), &schedule.ListRequest{
+	res, err := scheduleClient.List(ctx, &schedule.ListRequest{
 		Expand: &expand,
 	})
 	if err != nil {
@@ -271,7 +278,7 @@ func findAndDeleteDefaultSchedule(teamName string, config *client.Config) error
 		ownerTeam := sched.OwnerTeam
 		if ownerTeam != nil {
 			if ownerTeam.Name == teamName {
-				_, err = scheduleClient.Delete(context.Background(), &schedule.DeleteRequest{
+				_, err = scheduleClient.Delete(ctx, &schedule.DeleteRequest{
 					IdentifierType:  schedule.Id,
 					IdentifierValue: sched.Id,
 				})
@@ -286,12 +293,12 @@ func findAndDeleteDefaultSchedule(teamName string, config *client.Config) error
 	return errors.New("Could not find any schedule name for this team")
 }
 
-func findAndDeleteDefaultEscalation(teamName string, config *client.Config) error {
-	escalationClient, err := escalation.NewClient(config)
+func findAndDeleteDefaultEscalation(ctx context.Context, teamName string, meta interface{}) error {
+	escalationClient, err := meta.(*OpsgenieClient).escalationClient()
 	if err != nil {
 		return err
 	}
-	res, err := escalationClient.List(context.Background())
+	res, err := escalationClient.List(ctx)
 	if err != nil {
 		return err
 	}
@@ -299,7 +306,7 @@ func findAndDeleteDefaultEscalation(teamName string, config *client.Config) erro
 		ownerTeam := escal.OwnerTeam
 		if ownerTeam != nil {
 			if ownerTeam.Name == teamName {
-				_, err = escalationClient.Delete(context.Background(), &escalation.DeleteRequest{
+				_, err = escalationClient.Delete(ctx, &escalation.DeleteRequest{
 					IdentifierType: escalation.Id,
 					Identifier:     escal.Id,
 				})
@@ -314,12 +321,12 @@ func findAndDeleteDefaultEscalation(teamName string, config *client.Config) erro
 	return errors.New("Could not find any escalation for this team")
 }
 
-func findAndUpdateDefaultRoutingRule(teamName string, config *client.Config) error {
-	teamClient, err := team.NewClient(config)
+func findAndUpdateDefaultRoutingRule(ctx context.Context, teamName string, meta interface{}) error {
+	teamClient, err := meta.(*OpsgenieClient).teamClient()
 	if err != nil {
 		return err
 	}
-	rules, err := teamClient.ListRoutingRules(context.Background(), &team.ListRoutingRulesRequest{
+	rules, err := teamClient.ListRoutingRules(ctx, &team.ListRoutingRulesRequest{
 		TeamIdentifierType:  team.Name,
 		TeamIdentifierValue: teamName,
 	})
@@ -328,7 +335,7 @@ func findAndUpdateDefaultRoutingRule(teamName string, config *client.Config) err
 	}
 
 	for _, rule := range rules.RoutingRules {
-		_, err := teamClient.UpdateRoutingRule(context.Background(), &team.UpdateRoutingRuleRequest{
+		_, err := teamClient.UpdateRoutingRule(ctx, &team.UpdateRoutingRuleRequest{
 			TeamIdentifierType:  team.Name,
 			TeamIdentifierValue: teamName,
 			RoutingRuleId:       rule.Id,
@@ -343,3 +350,20 @@ func findAndUpdateDefaultRoutingRule(teamName string, config *client.Config) err
 	}
 	return nil
 }
+
+func lookupOpsgenieTeam(identifierType team.Identifier) importLookup {
+	return func(ctx context.Context, meta interface{}, identifier string) (string, error) {
+		client, err := meta.(*OpsgenieClient).teamClient()
+		if err != nil {
+			return "", err
+		}
+		result, err := client.Get(ctx, &team.GetTeamRequest{
+			IdentifierType:  identifierType,
+			IdentifierValue: identifier,
+		})
+		if err != nil {
+			return "", err
+		}
+		return result.Id, nil
+	}
+}
@@ -0,0 +1,175 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/team"
+)
+
+func resourceOpsGenieTeamMembership() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOpsGenieTeamMembershipCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsGenieTeamMembershipRead),
+		UpdateContext: resourceOpsGenieTeamMembershipUpdate,
+		DeleteContext: resourceOpsGenieTeamMembershipDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				teamId, userId, err := parseOpsGenieTeamMembershipId(d.Id())
+				if err != nil {
+					return nil, err
+				}
+				d.Set("team_id", teamId)
+				d.Set("user_id", userId)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"username": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "user",
+			},
+		},
+	}
+}
+
+func resourceOpsGenieTeamMembershipCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).teamClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	teamId := d.Get("team_id").(string)
+	userId := d.Get("user_id").(string)
+
+	addRequest := &team.AddTeamMemberRequest{
+		TeamIdentifierType:  team.Id,
+		TeamIdentifierValue: teamId,
+		User: team.User{
+			ID: userId,
+		},
+		Role: d.Get("role").(string),
+	}
+
+	log.Printf("[INFO] Adding user '%s' to OpsGenie team '%s'", userId, teamId)
+
+	_, err = client.AddMember(ctx, addRequest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", teamId, userId))
+
+	return diag.FromErr(resourceOpsGenieTeamMembershipRead(ctx, d, meta))
+}
+
+func resourceOpsGenieTeamMembershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).teamClient()
+	if err != nil {
+		return err
+	}
+	teamId, userId, err := parseOpsGenieTeamMembershipId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	getResponse, err := client.Get(ctx, &team.GetTeamRequest{
+		IdentifierType:  team.Id,
+		IdentifierValue: teamId,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, member := range getResponse.Members {
+		if member.User.ID == userId {
+			d.Set("team_id", teamId)
+			d.Set("user_id", userId)
+			d.Set("username", member.User.Username)
+			d.Set("role", member.Role)
+			return nil
+		}
+	}
+
+	log.Printf("[WARN] User '%s' is no longer a member of OpsGenie team '%s'", userId, teamId)
+	d.SetId("")
+	return nil
+}
+
+func resourceOpsGenieTeamMembershipUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).teamClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	teamId := d.Get("team_id").(string)
+	userId := d.Get("user_id").(string)
+
+	// Adding an existing member again updates its role in place.
+	addRequest := &team.AddTeamMemberRequest{
+		TeamIdentifierType:  team.Id,
+		TeamIdentifierValue: teamId,
+		User: team.User{
+			ID: userId,
+		},
+		Role: d.Get("role").(string),
+	}
+
+	log.Printf("[INFO] Updating role of user '%s' in OpsGenie team '%s'", userId, teamId)
+
+	_, err = client.AddMember(ctx, addRequest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(resourceOpsGenieTeamMembershipRead(ctx, d, meta))
+}
+
+func resourceOpsGenieTeamMembershipDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).teamClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	teamId := d.Get("team_id").(string)
+	userId := d.Get("user_id").(string)
+
+	log.Printf("[INFO] Removing user '%s' from OpsGenie team '%s'", userId, teamId)
+
+	_, err = client.RemoveMember(ctx, &team.RemoveTeamMemberRequest{
+		TeamIdentifierType:    team.Id,
+		TeamIdentifierValue:   teamId,
+		MemberIdentifierType:  team.Id,
+		MemberIdentifierValue: userId,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func parseOpsGenieTeamMembershipId(id string) (string, string, error) {
+	idParts := strings.Split(id, "/")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return "", "", fmt.Errorf("Unexpected format of ID (%q), expected team_id/user_id", id)
+	}
+	return idParts[0], idParts[1], nil
+}
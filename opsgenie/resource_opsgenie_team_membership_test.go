@@ -0,0 +1,119 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/team"
+)
+
+func TestAccOpsGenieTeamMembership_basic(t *testing.T) {
+	randomUser := acctest.RandString(6)
+	randomTeam := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckOpsGenieTeamMembershipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpsGenieTeamMembership_basic(randomUser, randomTeam, "user"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieTeamMembershipExists("opsgenie_team_membership.test"),
+					resource.TestCheckResourceAttr("opsgenie_team_membership.test", "role", "user"),
+				),
+			},
+			{
+				Config: testAccOpsGenieTeamMembership_basic(randomUser, randomTeam, "admin"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieTeamMembershipExists("opsgenie_team_membership.test"),
+					resource.TestCheckResourceAttr("opsgenie_team_membership.test", "role", "admin"),
+				),
+			},
+			{
+				ResourceName:      "opsgenie_team_membership.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckOpsGenieTeamMembershipDestroy(s *terraform.State) error {
+	client, err := team.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "opsgenie_team_membership" {
+			continue
+		}
+
+		resp, err := client.Get(context.Background(), &team.GetTeamRequest{
+			IdentifierType:  team.Id,
+			IdentifierValue: rs.Primary.Attributes["team_id"],
+		})
+		if err != nil {
+			// the team itself is gone as well
+			continue
+		}
+		for _, member := range resp.Members {
+			if member.User.ID == rs.Primary.Attributes["user_id"] {
+				return fmt.Errorf("Team membership %q still exists", rs.Primary.ID)
+			}
+		}
+	}
+	return nil
+}
+
+func testCheckOpsGenieTeamMembershipExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		client, err := team.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Get(context.Background(), &team.GetTeamRequest{
+			IdentifierType:  team.Id,
+			IdentifierValue: rs.Primary.Attributes["team_id"],
+		})
+		if err != nil {
+			return err
+		}
+		for _, member := range resp.Members {
+			if member.User.ID == rs.Primary.Attributes["user_id"] {
+				return nil
+			}
+		}
+		return fmt.Errorf("Bad: Team membership %q does not exist", rs.Primary.ID)
+	}
+}
+
+func testAccOpsGenieTeamMembership_basic(randomUser, randomTeam, role string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_user" "test" {
+  username  = "genietest-%s@opsgenie.com"
+  full_name = "Acceptance Test User"
+  role      = "User"
+}
+
+resource "opsgenie_team" "test" {
+  name           = "genieteam-%s"
+  description    = "This team deals with all the things"
+  ignore_members = true
+}
+
+resource "opsgenie_team_membership" "test" {
+  team_id = "${opsgenie_team.test.id}"
+  user_id = "${opsgenie_user.test.id}"
+  role    = "%s"
+}
+`, randomUser, randomTeam, role)
+}
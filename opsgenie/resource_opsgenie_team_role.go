@@ -0,0 +1,226 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/team"
+)
+
+var validTeamRoleRights = []string{
+	"manage-members",
+	"edit-team-roles",
+	"delete-team-roles",
+	"access-member-profiles",
+	"edit-member-profiles",
+	"edit-routing-rules",
+	"delete-routing-rules",
+	"edit-escalations",
+	"delete-escalations",
+	"edit-schedules",
+	"delete-schedules",
+	"edit-integrations",
+	"delete-integrations",
+	"edit-heartbeats",
+	"delete-heartbeats",
+	"access-reports",
+	"edit-services",
+	"delete-services",
+	"edit-rooms",
+	"delete-rooms",
+	"send-service-status-update",
+	"edit-automation-actions",
+	"delete-automation-actions",
+	"access-audit-logs",
+}
+
+func resourceOpsGenieTeamRole() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOpsGenieTeamRoleCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsGenieTeamRoleRead),
+		UpdateContext: resourceOpsGenieTeamRoleUpdate,
+		DeleteContext: resourceOpsGenieTeamRoleDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idParts := strings.Split(d.Id(), "/")
+				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+					return nil, fmt.Errorf("Unexpected format of ID (%q), expected team_id/team_role_id", d.Id())
+				}
+				d.Set("team_id", idParts[0])
+				d.SetId(idParts[1])
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"granted_rights": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(validTeamRoleRights, false),
+				},
+				Set: schema.HashString,
+			},
+			"not_granted_rights": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(validTeamRoleRights, false),
+				},
+				Set: schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceOpsGenieTeamRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).teamClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	teamId := d.Get("team_id").(string)
+	name := d.Get("name").(string)
+
+	rights, err := expandOpsGenieTeamRoleRights(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Creating OpsGenie team role '%s' for team '%s'", name, teamId)
+
+	result, err := client.CreateRole(ctx, &team.CreateTeamRoleRequest{
+		TeamIdentifierType:  team.Id,
+		TeamIdentifierValue: teamId,
+		Name:                name,
+		Rights:              rights,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(result.Id)
+
+	return diag.FromErr(resourceOpsGenieTeamRoleRead(ctx, d, meta))
+}
+
+func resourceOpsGenieTeamRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).teamClient()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading OpsGenie team role '%s'", d.Get("name").(string))
+
+	result, err := client.GetRole(ctx, &team.GetTeamRoleRequest{
+		TeamID: d.Get("team_id").(string),
+		RoleID: d.Id(),
+	})
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", result.Name)
+	granted, notGranted := flattenOpsGenieTeamRoleRights(result.Rights, d.Get("not_granted_rights").(*schema.Set))
+	d.Set("granted_rights", granted)
+	d.Set("not_granted_rights", notGranted)
+
+	return nil
+}
+
+func resourceOpsGenieTeamRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).teamClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name := d.Get("name").(string)
+
+	rights, err := expandOpsGenieTeamRoleRights(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Updating OpsGenie team role '%s'", name)
+
+	_, err = client.UpdateRole(ctx, &team.UpdateTeamRoleRequest{
+		TeamID: d.Get("team_id").(string),
+		RoleID: d.Id(),
+		Name:   name,
+		Rights: rights,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(resourceOpsGenieTeamRoleRead(ctx, d, meta))
+}
+
+func resourceOpsGenieTeamRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).teamClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Deleting OpsGenie team role '%s'", d.Get("name").(string))
+
+	_, err = client.DeleteRole(ctx, &team.DeleteTeamRoleRequest{
+		TeamID: d.Get("team_id").(string),
+		RoleID: d.Id(),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func expandOpsGenieTeamRoleRights(d *schema.ResourceData) ([]team.Right, error) {
+	granted := flattenSet(d.Get("granted_rights").(*schema.Set))
+	notGranted := flattenSet(d.Get("not_granted_rights").(*schema.Set))
+	rights := make([]team.Right, 0, len(granted)+len(notGranted))
+
+	for _, right := range granted {
+		if d.Get("not_granted_rights").(*schema.Set).Contains(right) {
+			return nil, fmt.Errorf("right %q cannot be both granted and not granted", right)
+		}
+		isGranted := true
+		rights = append(rights, team.Right{Right: right, Granted: &isGranted})
+	}
+	for _, right := range notGranted {
+		isGranted := false
+		rights = append(rights, team.Right{Right: right, Granted: &isGranted})
+	}
+
+	return rights, nil
+}
+
+// flattenOpsGenieTeamRoleRights splits the rights of a team role into granted
+// and not granted ones. Opsgenie returns every right of the role, so rights that
+// are not granted are only kept in state if they were configured explicitly.
+func flattenOpsGenieTeamRoleRights(input []team.Right, configuredNotGranted *schema.Set) ([]string, []string) {
+	granted := make([]string, 0)
+	notGranted := make([]string, 0)
+	for _, r := range input {
+		if r.Granted != nil && *r.Granted {
+			granted = append(granted, r.Right)
+		} else if configuredNotGranted.Contains(r.Right) {
+			notGranted = append(notGranted, r.Right)
+		}
+	}
+	return granted, notGranted
+}
@@ -0,0 +1,99 @@
+package opsgenie
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	ogClient "github.com/opsgenie/opsgenie-go-sdk-v2/client"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/team"
+)
+
+func TestAccOpsGenieTeamRole_basic(t *testing.T) {
+	randomTeam := acctest.RandString(6)
+	randomRole := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckOpsGenieTeamRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpsGenieTeamRole_basic(randomTeam, randomRole),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieTeamRoleExists("opsgenie_team_role.test"),
+					resource.TestCheckResourceAttr("opsgenie_team_role.test", "granted_rights.#", "2"),
+					resource.TestCheckResourceAttr("opsgenie_team_role.test", "not_granted_rights.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckOpsGenieTeamRoleDestroy(s *terraform.State) error {
+	client, err := team.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "opsgenie_team_role" {
+			continue
+		}
+
+		_, err := client.GetRole(context.Background(), &team.GetTeamRoleRequest{
+			TeamID: rs.Primary.Attributes["team_id"],
+			RoleID: rs.Primary.Attributes["id"],
+		})
+		if err != nil {
+			x := err.(*ogClient.ApiError)
+			if x.StatusCode != 404 {
+				return errors.New(fmt.Sprintf("Team role still exists : %s", x.Error()))
+			}
+		}
+	}
+	return nil
+}
+
+func testCheckOpsGenieTeamRoleExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		id := rs.Primary.Attributes["id"]
+		teamId := rs.Primary.Attributes["team_id"]
+
+		client, err := team.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+		if err != nil {
+			return err
+		}
+		_, err = client.GetRole(context.Background(), &team.GetTeamRoleRequest{
+			TeamID: teamId,
+			RoleID: id,
+		})
+		if err != nil {
+			return fmt.Errorf("Bad: TeamRole with id %q (teamId: %q) does not exist", id, teamId)
+		}
+		return nil
+	}
+}
+
+func testAccOpsGenieTeamRole_basic(randomTeam, randomRole string) string {
+	return fmt.Sprintf(`
+resource "opsgenie_team" "test" {
+  name        = "genieteam-%s"
+  description = "This team deals with all the things"
+}
+
+resource "opsgenie_team_role" "test" {
+  team_id            = "${opsgenie_team.test.id}"
+  name               = "genierole-%s"
+  granted_rights     = ["manage-members", "edit-schedules"]
+  not_granted_rights = ["delete-schedules"]
+}
+`, randomTeam, randomRole)
+}
@@ -9,18 +9,21 @@ import (
 
 	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/team"
 )
 
 func resourceOpsGenieTeamRoutingRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsGenieTeamRoutingRuleCreate,
-		Read:   handleNonExistentResource(resourceOpsGenieTeamRoutingRuleRead),
-		Update: resourceOpsGenieTeamRoutingRuleUpdate,
-		Delete: resourceOpsGenieTeamRoutingRuleDelete,
+		CreateContext: resourceOpsGenieTeamRoutingRuleCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsGenieTeamRoutingRuleRead),
+		UpdateContext: resourceOpsGenieTeamRoutingRuleUpdate,
+		DeleteContext: resourceOpsGenieTeamRoutingRuleDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: validateConditionsDiff(conditionsBlock{path: []string{"criteria"}, matchType: "type"}),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idParts := strings.Split(d.Id(), "/")
 				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 					return nil, fmt.Errorf("Unexpected format of ID (%q), expected team_id/routing_rule_id", d.Id())
@@ -49,6 +52,7 @@ func resourceOpsGenieTeamRoutingRule() *schema.Resource {
 			"order": {
 				Type:     schema.TypeInt,
 				Optional: true,
+				Computed: true,
 			},
 			"timezone": {
 				Type:     schema.TypeString,
@@ -84,42 +88,9 @@ func resourceOpsGenieTeamRoutingRule() *schema.Resource {
 						"type": {
 							Type:         schema.TypeString,
 							Required:     true,
-							ValidateFunc: validation.StringInSlice([]string{"match-all", "match-any-condition", "match-all-conditions"}, false),
-						},
-
-						"conditions": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"field": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-									"key": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									"not": {
-										Type:     schema.TypeBool,
-										Optional: true,
-										Default:  false,
-									},
-									"operation": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-									"expected_value": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									"order": {
-										Type:     schema.TypeInt,
-										Optional: true,
-									},
-								},
-							},
+							ValidateFunc: validation.StringInSlice(conditionMatchTypes, false),
 						},
+						"conditions": conditionsSchema(schema.TypeList, criteriaConditionFields),
 					},
 				},
 			},
@@ -128,10 +99,10 @@ func resourceOpsGenieTeamRoutingRule() *schema.Resource {
 	}
 }
 
-func resourceOpsGenieTeamRoutingRuleCreate(d *schema.ResourceData, meta interface{}) error {
-	client, err := team.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieTeamRoutingRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).teamClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	name := d.Get("name").(string)
 	teamId := d.Get("team_id").(string)
@@ -141,18 +112,13 @@ func resourceOpsGenieTeamRoutingRuleCreate(d *schema.ResourceData, meta interfac
 	criteria := d.Get("criteria").([]interface{})
 	notify := d.Get("notify").([]interface{})
 
-	expandedCriteria := expandOpsgenieCriteria(criteria)
-	if err := validateOpsgenieCriteria(expandedCriteria); err != nil {
-		return err
-	}
-
 	createRequest := &team.CreateRoutingRuleRequest{
 		TeamIdentifierType:  team.Id,
 		TeamIdentifierValue: teamId,
 		Name:                name,
 		Order:               &order,
 		Timezone:            timezone,
-		Criteria:            expandedCriteria,
+		Criteria:            expandOpsgenieCriteria(criteria),
 		Notify:              expandOpsgenieNotify(notify),
 	}
 
@@ -162,17 +128,17 @@ func resourceOpsGenieTeamRoutingRuleCreate(d *schema.ResourceData, meta interfac
 
 	log.Printf("[INFO] Creating OpsGenie team routing rule '%s'", name)
 
-	result, err := client.CreateRoutingRule(context.Background(), createRequest)
+	result, err := client.CreateRoutingRule(ctx, createRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(result.Id)
 
-	return resourceOpsGenieTeamRoutingRuleRead(d, meta)
+	return diag.FromErr(resourceOpsGenieTeamRoutingRuleRead(ctx, d, meta))
 }
 
-func resourceOpsGenieTeamRoutingRuleRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := team.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieTeamRoutingRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).teamClient()
 	if err != nil {
 		return err
 	}
@@ -183,7 +149,7 @@ func resourceOpsGenieTeamRoutingRuleRead(d *schema.ResourceData, meta interface{
 		RoutingRuleId:       d.Id(),
 	}
 
-	result, err := client.GetRoutingRule(context.Background(), getRequest)
+	result, err := client.GetRoutingRule(ctx, getRequest)
 	if err != nil {
 		return err
 	}
@@ -198,10 +164,10 @@ func resourceOpsGenieTeamRoutingRuleRead(d *schema.ResourceData, meta interface{
 	return nil
 }
 
-func resourceOpsGenieTeamRoutingRuleUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := team.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieTeamRoutingRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).teamClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	name := d.Get("name").(string)
 	order := d.Get("order").(int)
@@ -212,17 +178,12 @@ func resourceOpsGenieTeamRoutingRuleUpdate(d *schema.ResourceData, meta interfac
 	notify := d.Get("notify").([]interface{})
 	isDefault := d.Get("is_default").(bool)
 
-	expandedCriteria := expandOpsgenieCriteria(criteria)
-	if err := validateOpsgenieCriteria(expandedCriteria); err != nil {
-		return err
-	}
-
 	updateRequest := &team.UpdateRoutingRuleRequest{
 		TeamIdentifierType:  team.Id,
 		TeamIdentifierValue: teamId,
 		RoutingRuleId:       d.Id(),
 		Name:                name,
-		Criteria:            expandedCriteria,
+		Criteria:            expandOpsgenieCriteria(criteria),
 		Notify:              expandOpsgenieNotify(notify),
 	}
 	if len(timeRestriction) > 0 {
@@ -234,31 +195,31 @@ func resourceOpsGenieTeamRoutingRuleUpdate(d *schema.ResourceData, meta interfac
 	}
 
 	log.Printf("[INFO] Updating OpsGenie team routing rule '%s'", name)
-	_, err = client.UpdateRoutingRule(context.Background(), updateRequest)
+	_, err = client.UpdateRoutingRule(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	if !isDefault {
-		_, err = client.ChangeRoutingRuleOrder(context.Background(), &team.ChangeRoutingRuleOrderRequest{
+	if !isDefault && d.HasChange("order") {
+		_, err = client.ChangeRoutingRuleOrder(ctx, &team.ChangeRoutingRuleOrderRequest{
 			RoutingRuleId:       d.Id(),
 			TeamIdentifierType:  team.Id,
 			TeamIdentifierValue: teamId,
 			Order:               &order,
 		})
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	return nil
 }
 
-func resourceOpsGenieTeamRoutingRuleDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsGenieTeamRoutingRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Deleting OpsGenie team routing rule'%s'", d.Get("name").(string))
-	client, err := team.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).teamClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	deleteRequest := &team.DeleteRoutingRuleRequest{
 		TeamIdentifierType:  team.Id,
@@ -266,9 +227,9 @@ func resourceOpsGenieTeamRoutingRuleDelete(d *schema.ResourceData, meta interfac
 		RoutingRuleId:       d.Id(),
 	}
 
-	_, err = client.DeleteRoutingRule(context.Background(), deleteRequest)
+	_, err = client.DeleteRoutingRule(ctx, deleteRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -289,20 +250,7 @@ func flattenOpsgenieCriteria(input og.Criteria) []map[string]interface{} {
 	rules := make([]map[string]interface{}, 0, 1)
 	out := make(map[string]interface{})
 	out["type"] = input.CriteriaType
-	conditions := make([]map[string]interface{}, 0, len(input.Conditions))
-	for _, r := range input.Conditions {
-		conditionMap := make(map[string]interface{})
-		conditionMap["order"] = r.Order
-		if r.Key != "" {
-			conditionMap["key"] = r.Key
-		}
-		conditionMap["expected_value"] = r.ExpectedValue
-		conditionMap["operation"] = r.Operation
-		conditionMap["field"] = r.Field
-		conditionMap["not"] = r.IsNot
-		conditions = append(conditions, conditionMap)
-	}
-	out["conditions"] = conditions
+	out["conditions"] = flattenOpsgenieConditions(input.Conditions, true)
 	rules = append(rules, out)
 	return rules
 }
@@ -328,13 +276,6 @@ func expandOpsgenieNotify(input []interface{}) *team.Notify {
 
 }
 
-func validateOpsgenieCriteria(criteria *og.Criteria) error {
-	if criteria.CriteriaType == "match-all" && len(criteria.Conditions) > 0 {
-		return fmt.Errorf("criteria cannot have conditions set when type is match-all: %v", criteria)
-	}
-	return nil
-}
-
 func expandOpsgenieCriteria(input []interface{}) *og.Criteria {
 
 	criteria := og.Criteria{}
@@ -347,30 +288,3 @@ func expandOpsgenieCriteria(input []interface{}) *og.Criteria {
 	}
 	return &criteria
 }
-
-func expandOpsgenieConditions(input []interface{}) []og.Condition {
-
-	conditions := make([]og.Condition, 0, len(input))
-
-	if input == nil {
-		return conditions
-	}
-	for _, v := range input {
-		inputMap := v.(map[string]interface{})
-		condition := og.Condition{}
-		condition.Field = og.ConditionFieldType(inputMap["field"].(string))
-		isNot := inputMap["not"].(bool)
-		condition.IsNot = &isNot
-		condition.Operation = og.ConditionOperation(inputMap["operation"].(string))
-		condition.ExpectedValue = inputMap["expected_value"].(string)
-		key := inputMap["key"].(string)
-		if key != "" {
-			condition.Key = inputMap["key"].(string)
-		}
-		order := inputMap["order"].(int)
-		condition.Order = &order
-		conditions = append(conditions, condition)
-	}
-
-	return conditions
-}
@@ -0,0 +1,111 @@
+package opsgenie
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/team"
+)
+
+// resourceOpsGenieTeamRoutingRuleOrder manages the order of the routing rules
+// of a team. The listed rules are moved to the top, in the given order, and
+// the other rules follow them. The default rule always comes last.
+func resourceOpsGenieTeamRoutingRuleOrder() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOpsGenieTeamRoutingRuleOrderUpdate,
+		ReadContext:   handleNonExistentResource(resourceOpsGenieTeamRoutingRuleOrderRead),
+		UpdateContext: resourceOpsGenieTeamRoutingRuleOrderUpdate,
+		DeleteContext: resourceOpsGenieOrderDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"routing_rule_ids": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func listOpsGenieRoutingRuleIds(ctx context.Context, client *team.Client, teamId string) ([]string, error) {
+	result, err := client.ListRoutingRules(ctx, &team.ListRoutingRulesRequest{
+		TeamIdentifierType:  team.Id,
+		TeamIdentifierValue: teamId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(result.RoutingRules, func(i, j int) bool {
+		return result.RoutingRules[i].Order < result.RoutingRules[j].Order
+	})
+	ids := make([]string, 0, len(result.RoutingRules))
+	for _, rule := range result.RoutingRules {
+		if !rule.IsDefault {
+			ids = append(ids, rule.Id)
+		}
+	}
+	return ids, nil
+}
+
+func resourceOpsGenieTeamRoutingRuleOrderUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).teamClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	teamId := d.Get("team_id").(string)
+
+	current, err := listOpsGenieRoutingRuleIds(ctx, client, teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Ordering OpsGenie routing rules of team '%s'", teamId)
+	err = reorderOpsGenieIds(current, expandOpsGenieOrderIds(d.Get("routing_rule_ids")), func(id string, index int) error {
+		_, err := client.ChangeRoutingRuleOrder(ctx, &team.ChangeRoutingRuleOrderRequest{
+			TeamIdentifierType:  team.Id,
+			TeamIdentifierValue: teamId,
+			RoutingRuleId:       id,
+			Order:               &index,
+		})
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(teamId)
+
+	return diag.FromErr(resourceOpsGenieTeamRoutingRuleOrderRead(ctx, d, meta))
+}
+
+func resourceOpsGenieTeamRoutingRuleOrderRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).teamClient()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading OpsGenie routing rule order of team '%s'", d.Id())
+	current, err := listOpsGenieRoutingRuleIds(ctx, client, d.Id())
+	if err != nil {
+		return err
+	}
+
+	d.Set("team_id", d.Id())
+	d.Set("routing_rule_ids", orderedIdsPrefix(current, d.Get("routing_rule_ids")))
+	return nil
+}
@@ -0,0 +1,117 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/team"
+)
+
+func TestAccOpsGenieTeamRoutingRuleOrder_basic(t *testing.T) {
+	randomTeam := acctest.RandString(6)
+	randomRule := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpsGenieTeamRoutingRuleOrder_basic(randomTeam, randomRule, "b", "a"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieTeamRoutingRuleOrder("opsgenie_team_routing_rule_order.test", "b", "a"),
+				),
+			},
+			{
+				Config: testAccOpsGenieTeamRoutingRuleOrder_basic(randomTeam, randomRule, "a", "b"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpsGenieTeamRoutingRuleOrder("opsgenie_team_routing_rule_order.test", "a", "b"),
+				),
+			},
+			{
+				ResourceName:      "opsgenie_team_routing_rule_order.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testCheckOpsGenieTeamRoutingRuleOrder checks the order of the routing rules
+// by the suffixes of their names.
+func testCheckOpsGenieTeamRoutingRuleOrder(name string, suffixes ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		teamId := rs.Primary.Attributes["team_id"]
+
+		client, err := team.NewClient(testAccProvider.Meta().(*OpsgenieClient).client.Config)
+		if err != nil {
+			return err
+		}
+		ids, err := listOpsGenieRoutingRuleIds(context.Background(), client, teamId)
+		if err != nil {
+			return err
+		}
+		if len(ids) != len(suffixes) {
+			return fmt.Errorf("Bad: Expected %d routing rules, got %d", len(suffixes), len(ids))
+		}
+		for i, id := range ids {
+			result, err := client.GetRoutingRule(context.Background(), &team.GetRoutingRuleRequest{
+				TeamIdentifierType:  team.Id,
+				TeamIdentifierValue: teamId,
+				RoutingRuleId:       id,
+			})
+			if err != nil {
+				return err
+			}
+			if !strings.HasSuffix(result.Name, "-"+suffixes[i]) {
+				return fmt.Errorf("Bad: Expected routing rule %d to end with %q, got %q", i, suffixes[i], result.Name)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccOpsGenieTeamRoutingRuleOrder_basic(randomTeam, randomRule string, order ...string) string {
+	ids := make([]string, 0, len(order))
+	for _, o := range order {
+		ids = append(ids, fmt.Sprintf("opsgenie_team_routing_rule.%s.id", o))
+	}
+	return fmt.Sprintf(`
+resource "opsgenie_team" "test" {
+  name        = "genieteam-%s"
+  description = "This team deals with all the things"
+}
+resource "opsgenie_team_routing_rule" "a" {
+  name    = "genierule-%s-a"
+  team_id = opsgenie_team.test.id
+  criteria {
+    type = "match-all"
+  }
+  notify {
+    type = "none"
+  }
+}
+resource "opsgenie_team_routing_rule" "b" {
+  name    = "genierule-%s-b"
+  team_id = opsgenie_team.test.id
+  criteria {
+    type = "match-all"
+  }
+  notify {
+    type = "none"
+  }
+  depends_on = [opsgenie_team_routing_rule.a]
+}
+resource "opsgenie_team_routing_rule_order" "test" {
+  team_id          = opsgenie_team.test.id
+  routing_rule_ids = [%s]
+}
+`, randomTeam, randomRule, randomRule, strings.Join(ids, ", "))
+}
@@ -70,6 +70,17 @@ func TestAccOpsGenieTeam_basic(t *testing.T) {
 					testCheckOpsGenieTeamExists("opsgenie_team.test"),
 				),
 			},
+			{
+				ResourceName:  "opsgenie_team.test",
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("genieteam-%s", rs),
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].ID == states[0].Attributes["name"] {
+						return fmt.Errorf("Expected team imported by name to be stored by id, got %v", states)
+					}
+					return nil
+				},
+			},
 		},
 	})
 }
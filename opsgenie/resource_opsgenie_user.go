@@ -14,17 +14,25 @@ import (
 
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceOpsGenieUser() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsGenieUserCreate,
-		Read:   handleNonExistentResource(resourceOpsGenieUserRead),
-		Update: resourceOpsGenieUserUpdate,
-		Delete: resourceOpsGenieUserDelete,
+		CreateContext: resourceOpsGenieUserCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsGenieUserRead),
+		UpdateContext: resourceOpsGenieUserUpdate,
+		DeleteContext: resourceOpsGenieUserDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importStateByIdOrName("user", lookupOpsgenieUser),
 		},
 		Schema: map[string]*schema.Schema{
 			"username": {
@@ -169,11 +177,11 @@ func expandOpsGenieUserDetails(d *schema.ResourceData) map[string][]string {
 	return output
 }
 
-func resourceOpsGenieUserCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsGenieUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client, err := user.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).userClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	username := d.Get("username").(string)
 	fullName := d.Get("full_name").(string)
@@ -206,18 +214,18 @@ func resourceOpsGenieUserCreate(d *schema.ResourceData, meta interface{}) error
 	}
 
 	log.Printf("[INFO] Creating OpsGenie user '%s'", username)
-	result, err := client.Create(context.Background(), createRequest)
+	result, err := client.Create(ctx, createRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(result.Id)
 
-	return resourceOpsGenieUserRead(d, meta)
+	return diag.FromErr(resourceOpsGenieUserRead(ctx, d, meta))
 }
 
-func resourceOpsGenieUserRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := user.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).userClient()
 	if err != nil {
 		return err
 	}
@@ -225,7 +233,7 @@ func resourceOpsGenieUserRead(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[INFO] Reading OpsGenie user '%s'", username)
 
-	usr, err := client.Get(context.Background(), &user.GetRequest{
+	usr, err := client.Get(ctx, &user.GetRequest{
 		Identifier: d.Id(),
 	})
 	if err != nil {
@@ -246,10 +254,10 @@ func resourceOpsGenieUserRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceOpsGenieUserUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := user.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).userClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	username := d.Get("username").(string)
 	fullName := d.Get("full_name").(string)
@@ -283,56 +291,49 @@ func resourceOpsGenieUserUpdate(d *schema.ResourceData, meta interface{}) error
 		SkypeUsername: skypeUsername,
 	}
 
-	_, err = client.Update(context.Background(), updateRequest)
+	_, err = client.Update(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceOpsGenieUserDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsGenieUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Deleting OpsGenie user '%s'", d.Get("username").(string))
-	client, err := user.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).userClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	maxAttempt := 5
-	attempt := 0
-	for {
-		retry := false
-		attempt++
-
-		err = deleteUserFromTeams(client, d.Id(), meta)
+	// Deleting a user fails with 428 while it is still referenced somewhere
+	// else, so keep detaching it and retrying until the delete timeout expires.
+	err = resource.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		err := deleteUserFromTeams(ctx, client, d.Id(), meta)
 		if err != nil {
-			return err
+			return resource.NonRetryableError(err)
 		}
 
-		err = deleteUserFromScheduleRotations(client, d.Id(), meta)
+		err = deleteUserFromScheduleRotations(ctx, client, d.Id(), meta)
 		if err != nil {
-			return err
+			return resource.NonRetryableError(err)
 		}
 
 		deleteRequest := &user.DeleteRequest{
 			Identifier: d.Id(),
 		}
 
-		_, err = client.Delete(context.Background(), deleteRequest)
+		_, err = client.Delete(ctx, deleteRequest)
 		if err != nil {
 			if e, ok := err.(*ogClient.ApiError); ok && e.StatusCode == 428 {
-				if attempt == maxAttempt {
-					return err
-				}
-				retry = true
-			} else {
-				return err
+				return resource.RetryableError(err)
 			}
+			return resource.NonRetryableError(err)
 		}
-
-		if retry == false {
-			break
-		}
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -381,24 +382,24 @@ func flattenUserAddress(addr *user.UserAddress) []map[string]interface{} {
 	}}
 }
 
-func deleteUserFromTeams(client *user.Client, userId string, meta interface{}) error {
+func deleteUserFromTeams(ctx context.Context, client *user.Client, userId string, meta interface{}) error {
 	teamRequest := &user.ListUserTeamsRequest{
 		Identifier: userId,
 	}
-	teamResult, err := client.ListUserTeams(context.Background(), teamRequest)
+	teamResult, err := client.ListUserTeams(ctx, teamRequest)
 	if err != nil {
 		return err
 	}
 	for _, t := range teamResult.Teams {
 
-		tclient, err := team.NewClient(meta.(*OpsgenieClient).client.Config)
+		tclient, err := meta.(*OpsgenieClient).teamClient()
 		if err != nil {
 			return err
 		}
 
 		log.Printf("[INFO] Removing OpsGenie user '%s' from OpsGenie team '%s'", userId, t.Id)
 
-		_, err = tclient.RemoveMember(context.Background(), &team.RemoveTeamMemberRequest{
+		_, err = tclient.RemoveMember(ctx, &team.RemoveTeamMemberRequest{
 			TeamIdentifierType:    team.Id,
 			TeamIdentifierValue:   t.Id,
 			MemberIdentifierType:  team.Id,
@@ -411,12 +412,12 @@ func deleteUserFromTeams(client *user.Client, userId string, meta interface{}) e
 	return nil
 }
 
-func deleteUserFromScheduleRotations(client *user.Client, userId string, meta interface{}) error {
+func deleteUserFromScheduleRotations(ctx context.Context, client *user.Client, userId string, meta interface{}) error {
 	schedulesRequest := &user.ListUserSchedulesRequest{
 		Identifier: userId,
 	}
 
-	schedulesResult, err := client.ListUserSchedules(context.Background(), schedulesRequest)
+	schedulesResult, err := client.ListUserSchedules(ctx, schedulesRequest)
 	if err != nil {
 		return err
 	}
@@ -428,12 +429,12 @@ func deleteUserFromScheduleRotations(client *user.Client, userId string, meta in
 			ScheduleIdentifierValue: s.Id,
 		}
 
-		sclient, err := schedule.NewClient(meta.(*OpsgenieClient).client.Config)
+		sclient, err := meta.(*OpsgenieClient).scheduleClient()
 		if err != nil {
 			return err
 		}
 
-		scheduleRotationsResult, err := sclient.ListRotations(context.Background(), scheduleRotationsRequest)
+		scheduleRotationsResult, err := sclient.ListRotations(ctx, scheduleRotationsRequest)
 		if err != nil {
 			return err
 		}
@@ -454,7 +455,7 @@ func deleteUserFromScheduleRotations(client *user.Client, userId string, meta in
 						},
 					}
 
-					_, err := sclient.UpdateRotation(context.Background(), updateRotationRequest)
+					_, err := sclient.UpdateRotation(ctx, updateRotationRequest)
 					if err != nil {
 						return err
 					}
@@ -465,3 +466,19 @@ func deleteUserFromScheduleRotations(client *user.Client, userId string, meta in
 
 	return nil
 }
+
+// lookupOpsgenieUser resolves a user id or username, both of which are
+// accepted by the Opsgenie user API.
+func lookupOpsgenieUser(ctx context.Context, meta interface{}, identifier string) (string, error) {
+	client, err := meta.(*OpsgenieClient).userClient()
+	if err != nil {
+		return "", err
+	}
+	result, err := client.Get(ctx, &user.GetRequest{
+		Identifier: identifier,
+	})
+	if err != nil {
+		return "", err
+	}
+	return result.Id, nil
+}
@@ -7,17 +7,19 @@ import (
 
 	"github.com/opsgenie/opsgenie-go-sdk-v2/contact"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceOpsGenieUserContact() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpsGenieUserContactCreate,
-		Read:   handleNonExistentResource(resourceOpsGenieUserContactRead),
-		Update: resourceOpsGenieUserContactUpdate,
-		Delete: resourceOpsGenieUserContactDelete,
+		CreateContext: resourceOpsGenieUserContactCreate,
+		ReadContext:   handleNonExistentResource(resourceOpsGenieUserContactRead),
+		UpdateContext: resourceOpsGenieUserContactUpdate,
+		DeleteContext: resourceOpsGenieUserContactDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idParts := strings.Split(d.Id(), "/")
 				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 					return nil, fmt.Errorf("Unexpected format of ID (%q), expected username/contact_id", d.Id())
@@ -54,11 +56,11 @@ func resourceOpsGenieUserContact() *schema.Resource {
 	}
 }
 
-func resourceOpsGenieUserContactCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceOpsGenieUserContactCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client, err := contact.NewClient(meta.(*OpsgenieClient).client.Config)
+	client, err := meta.(*OpsgenieClient).contactClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	userId := d.Get("username").(string)
 	method := d.Get("method").(string)
@@ -71,41 +73,41 @@ func resourceOpsGenieUserContactCreate(d *schema.ResourceData, meta interface{})
 		MethodOfContact: contact.MethodType(method),
 	}
 
-	result, err := client.Create(context.Background(), createRequest)
+	result, err := client.Create(ctx, createRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(result.Id)
 
 	if enabled {
-		_, err = client.Enable(context.Background(), &contact.EnableRequest{
+		_, err = client.Enable(ctx, &contact.EnableRequest{
 			UserIdentifier:    userId,
 			ContactIdentifier: result.Id,
 		})
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
-		_, err = client.Disable(context.Background(), &contact.DisableRequest{
+		_, err = client.Disable(ctx, &contact.DisableRequest{
 			UserIdentifier:    userId,
 			ContactIdentifier: result.Id,
 		})
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	return resourceOpsGenieUserContactRead(d, meta)
+	return diag.FromErr(resourceOpsGenieUserContactRead(ctx, d, meta))
 }
 
-func resourceOpsGenieUserContactRead(d *schema.ResourceData, meta interface{}) error {
-	client, err := contact.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieUserContactRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*OpsgenieClient).contactClient()
 	if err != nil {
 		return err
 	}
 	userId := d.Get("username").(string)
 
-	contactsResult, err := client.Get(context.Background(), &contact.GetRequest{
+	contactsResult, err := client.Get(ctx, &contact.GetRequest{
 		UserIdentifier:    userId,
 		ContactIdentifier: d.Id(),
 	})
@@ -120,10 +122,10 @@ func resourceOpsGenieUserContactRead(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
-func resourceOpsGenieUserContactUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := contact.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieUserContactUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).contactClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	userId := d.Get("username").(string)
 	enabled := d.Get("enabled").(bool)
@@ -134,34 +136,34 @@ func resourceOpsGenieUserContactUpdate(d *schema.ResourceData, meta interface{})
 		ContactIdentifier: d.Id(),
 		To:                to,
 	}
-	_, err = client.Update(context.Background(), updateRequest)
+	_, err = client.Update(ctx, updateRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if enabled {
-		_, err = client.Enable(context.Background(), &contact.EnableRequest{
+		_, err = client.Enable(ctx, &contact.EnableRequest{
 			UserIdentifier:    userId,
 			ContactIdentifier: d.Id(),
 		})
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
-		_, err = client.Disable(context.Background(), &contact.DisableRequest{
+		_, err = client.Disable(ctx, &contact.DisableRequest{
 			UserIdentifier:    userId,
 			ContactIdentifier: d.Id(),
 		})
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	return nil
 }
 
-func resourceOpsGenieUserContactDelete(d *schema.ResourceData, meta interface{}) error {
-	client, err := contact.NewClient(meta.(*OpsgenieClient).client.Config)
+func resourceOpsGenieUserContactDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*OpsgenieClient).contactClient()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	userId := d.Get("username").(string)
 
@@ -170,9 +172,9 @@ func resourceOpsGenieUserContactDelete(d *schema.ResourceData, meta interface{})
 		ContactIdentifier: d.Id(),
 	}
 
-	dr, err := client.Delete(context.Background(), deleteRequest)
+	dr, err := client.Delete(ctx, deleteRequest)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	_ = dr
 
@@ -9,6 +9,13 @@ import (
 )
 
 func TestMain(m *testing.M) {
+	// OPSGENIE_FAKE_API runs the acceptance tests and sweepers against an
+	// in-memory Opsgenie API instead of a real account.
+	if os.Getenv("OPSGENIE_FAKE_API") != "" {
+		api := newFakeOpsgenieAPI("fake-api-key")
+		os.Setenv("OPSGENIE_API_KEY", api.apiKey)
+		os.Setenv("OPSGENIE_API_URL", api.Host())
+	}
 	resource.TestMain(m)
 }
 
@@ -19,6 +26,7 @@ func sharedConfigForRegion() (interface{}, error) {
 
 	config := Config{
 		ApiKey: os.Getenv("OPSGENIE_API_KEY"),
+		ApiUrl: os.Getenv("OPSGENIE_API_URL"),
 	}
 
 	client, err := config.Client()
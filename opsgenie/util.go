@@ -1,10 +1,13 @@
 package opsgenie
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/client"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
@@ -12,15 +15,15 @@ import (
 
 // handleNonExistentResource is a wrapper of resourceFunc that
 // handles errors returned by a read function.
-func handleNonExistentResource(f schema.ReadFunc) schema.ReadFunc {
-	return func(d *schema.ResourceData, meta interface{}) error {
-		if err := f(d, meta); err != nil {
+func handleNonExistentResource(f func(context.Context, *schema.ResourceData, interface{}) error) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if err := f(ctx, d, meta); err != nil {
 			// if the error that we receive is an ApiError and
 			// the status code is 404, it means we need to re-create
 			// the specific resource
 			apiErr, ok := err.(*client.ApiError)
 			if !ok || apiErr.StatusCode != http.StatusNotFound {
-				return err
+				return diag.FromErr(err)
 			}
 			d.SetId("")
 			return nil
@@ -30,6 +33,57 @@ func handleNonExistentResource(f schema.ReadFunc) schema.ReadFunc {
 	}
 }
 
+// importLookup resolves an import identifier to the id of an Opsgenie entity.
+type importLookup func(ctx context.Context, meta interface{}, identifier string) (string, error)
+
+// importStateByIdOrName returns an importer which accepts any identifier the
+// given lookups can resolve, e.g. an id or a name. Lookups answering with 404
+// are skipped and the identifier has to resolve to exactly one entity.
+func importStateByIdOrName(kind string, lookups ...importLookup) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		identifier := d.Id()
+		var ids []string
+		for _, lookup := range lookups {
+			id, err := lookup(ctx, meta, identifier)
+			if err != nil {
+				apiErr, ok := err.(*client.ApiError)
+				if !ok || apiErr.StatusCode != http.StatusNotFound {
+					return nil, err
+				}
+				continue
+			}
+			duplicate := false
+			for _, existing := range ids {
+				duplicate = duplicate || existing == id
+			}
+			if !duplicate {
+				ids = append(ids, id)
+			}
+		}
+
+		switch len(ids) {
+		case 0:
+			return nil, fmt.Errorf("no %s found with id or name %q", kind, identifier)
+		case 1:
+			d.SetId(ids[0])
+			return []*schema.ResourceData{d}, nil
+		default:
+			return nil, fmt.Errorf("%q is ambiguous, it matches the %ss with ids %s", identifier, kind, strings.Join(ids, ", "))
+		}
+	}
+}
+
+// defaultResourceTimeouts returns the timeouts used by resources which
+// don't need more time than a few API round trips.
+func defaultResourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(5 * time.Minute),
+		Read:   schema.DefaultTimeout(5 * time.Minute),
+		Update: schema.DefaultTimeout(5 * time.Minute),
+		Delete: schema.DefaultTimeout(5 * time.Minute),
+	}
+}
+
 func validateDateWithMinutes(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 
@@ -184,3 +238,9 @@ func flattenOpsgenieTimeRestriction(input *og.TimeRestriction) []map[string]inte
 	output = append(output, element)
 	return output
 }
+
+// listDataSourceId builds a stable id for data sources returning a list of
+// objects, based on the ids of the returned objects.
+func listDataSourceId(ids []string) string {
+	return fmt.Sprintf("%d", schema.HashString(strings.Join(ids, ",")))
+}
@@ -0,0 +1,64 @@
+package opsgenie
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/client"
+)
+
+func TestImportStateByIdOrName(t *testing.T) {
+	entities := map[string]string{
+		"a1": "first",
+		"b2": "second",
+		"c3": "a1",
+	}
+	byId := func(ctx context.Context, meta interface{}, identifier string) (string, error) {
+		if _, ok := entities[identifier]; ok {
+			return identifier, nil
+		}
+		return "", &client.ApiError{StatusCode: http.StatusNotFound}
+	}
+	byName := func(ctx context.Context, meta interface{}, identifier string) (string, error) {
+		for id, name := range entities {
+			if name == identifier {
+				return id, nil
+			}
+		}
+		return "", &client.ApiError{StatusCode: http.StatusNotFound}
+	}
+	importer := importStateByIdOrName("team", byId, byName)
+
+	cases := []struct {
+		identifier string
+		id         string
+		err        string
+	}{
+		{identifier: "b2", id: "b2"},
+		{identifier: "second", id: "b2"},
+		{identifier: "missing", err: "no team found"},
+		{identifier: "a1", err: "ambiguous"},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+		d.SetId(c.identifier)
+
+		states, err := importer(context.Background(), d, nil)
+		if c.err != "" {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("Expected importing %q to fail with %q, got %v", c.identifier, c.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Importing %q failed: %s", c.identifier, err)
+			continue
+		}
+		if states[0].Id() != c.id {
+			t.Errorf("Expected %q to be imported as %q, got %q", c.identifier, c.id, states[0].Id())
+		}
+	}
+}